@@ -0,0 +1,131 @@
+package distributed
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// NodeStatus содержит последнее известное состояние узла
+type NodeStatus struct {
+	Node       int
+	Generation int
+	Best       Genome
+	Done       bool
+}
+
+// Coordinator собирает отчёты узлов и хранит глобально лучшее решение
+type Coordinator struct {
+	ListenAddr string
+	Nodes      int // Ожидаемое число узлов; Run завершается, когда все они сообщили Done
+
+	// OnImprovement вызывается при улучшении глобально лучшего решения
+	OnImprovement func(status NodeStatus)
+
+	listener net.Listener
+
+	mu       sync.Mutex
+	statuses map[int]NodeStatus
+	best     NodeStatus
+	hasBest  bool
+	finished chan struct{}
+}
+
+// NewCoordinator создаёт координатор, ожидающий nodes узлов
+func NewCoordinator(listenAddr string, nodes int) *Coordinator {
+	return &Coordinator{
+		ListenAddr: listenAddr,
+		Nodes:      nodes,
+		statuses:   make(map[int]NodeStatus),
+		finished:   make(chan struct{}),
+	}
+}
+
+// Listen открывает адрес приёма отчётов и возвращает фактический адрес
+// (при порте 0 он становится известен только здесь). Run вызывает Listen сам,
+// если координатор ещё не слушает
+func (c *Coordinator) Listen() (string, error) {
+	if c.listener == nil {
+		listener, err := net.Listen("tcp", c.ListenAddr)
+		if err != nil {
+			return "", err
+		}
+		c.listener = listener
+		go serve(listener, c.handle)
+	}
+	return c.listener.Addr().String(), nil
+}
+
+// Run принимает отчёты до завершения всех узлов или отмены ctx
+func (c *Coordinator) Run(ctx context.Context) error {
+	if _, err := c.Listen(); err != nil {
+		return err
+	}
+	defer func() {
+		c.listener.Close()
+		c.listener = nil
+	}()
+
+	select {
+	case <-c.finished:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (c *Coordinator) handle(msg Message) {
+	if msg.Type != MsgReport || msg.Best == nil {
+		return
+	}
+	status := NodeStatus{Node: msg.Node, Generation: msg.Generation, Best: *msg.Best, Done: msg.Done}
+
+	c.mu.Lock()
+	// Соединения обрабатываются параллельно, поэтому отчёты могут прийти не по порядку
+	if prev, ok := c.statuses[msg.Node]; ok {
+		status.Done = status.Done || prev.Done
+		if prev.Generation > status.Generation {
+			status.Generation = prev.Generation
+		}
+	}
+	c.statuses[msg.Node] = status
+	improved := !c.hasBest || status.Best.Fitness > c.best.Best.Fitness
+	if improved {
+		c.best = status
+		c.hasBest = true
+	}
+	allDone := c.Nodes > 0 && len(c.statuses) >= c.Nodes
+	for _, st := range c.statuses {
+		allDone = allDone && st.Done
+	}
+	callback := c.OnImprovement
+	c.mu.Unlock()
+
+	if improved && callback != nil {
+		callback(status)
+	}
+	if allDone {
+		select {
+		case <-c.finished:
+		default:
+			close(c.finished)
+		}
+	}
+}
+
+// Best возвращает глобально лучшее решение и признак его наличия
+func (c *Coordinator) Best() (NodeStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.best, c.hasBest
+}
+
+// Statuses возвращает последние отчёты всех узлов
+func (c *Coordinator) Statuses() []NodeStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]NodeStatus, 0, len(c.statuses))
+	for _, st := range c.statuses {
+		out = append(out, st)
+	}
+	return out
+}
@@ -0,0 +1,185 @@
+package distributed
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"Genetic-algorithm/backend/genetic"
+)
+
+// newTestNode создаёт узел на свободном локальном порту. Число поколений не ограничивает
+// работу: узлы останавливаются отменой контекста
+func newTestNode(t *testing.T, id int, coordinator string) *Node {
+	t.Helper()
+	graph := genetic.PredefinedGraphs()["Great grid 25x40 (1000)"].ToGraph()
+	ga, err := genetic.NewGeneticAlgorithm(
+		&graph,
+		genetic.Classic,
+		&genetic.SinglePoint{},
+		&genetic.TournamentSelectionStrategy{TournamentSize: 3},
+		&genetic.ClassicMutationStrategy{},
+		20, 1_000_000, 0.01, 0.9, 1, 2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := NewNode(NodeConfig{
+		ID:                id,
+		ListenAddr:        "127.0.0.1:0",
+		CoordinatorAddr:   coordinator,
+		MigrationInterval: 2,
+		Migration:         genetic.MigrationPolicy{Migrants: 2},
+	}, ga)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return node
+}
+
+// waitFor опрашивает cond до истечения timeout
+func waitFor(t *testing.T, timeout time.Duration, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestLoopbackRing запускает координатор и три узла по кольцу на 127.0.0.1 в одном процессе
+func TestLoopbackRing(t *testing.T) {
+	const nodes = 3
+
+	coordinator := NewCoordinator("127.0.0.1:0", nodes)
+	coordAddr, err := coordinator.Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordDone := make(chan error, 1)
+	go func() { coordDone <- coordinator.Run(context.Background()) }()
+
+	ring := make([]*Node, nodes)
+	addrs := make([]string, nodes)
+	for i := range ring {
+		ring[i] = newTestNode(t, i, coordAddr)
+		if addrs[i], err = ring[i].Listen(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, node := range ring {
+		node.Config.Neighbors = []string{addrs[(i+1)%nodes]}
+	}
+
+	// Мигрант с геномом другой длины должен быть отклонён
+	bad := Message{Type: MsgMigrants, Node: 99, Migrants: []Genome{{Genes: make([]bool, 7)}}}
+	if err := Send(addrs[0], bad); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 5*time.Second, "rejection of a wrong-length migrant", func() bool {
+		_, rejected := ring[0].Migrants()
+		return rejected == 1
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	best := make([]genetic.Chromosome, nodes)
+	var wg sync.WaitGroup
+	for i, node := range ring {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if best[i], err = node.Run(ctx); err != nil {
+				t.Errorf("node %d: %v", i, err)
+			}
+		}()
+	}
+
+	waitFor(t, 30*time.Second, "migrants on every node", func() bool {
+		for _, node := range ring {
+			if accepted, _ := node.Migrants(); accepted == 0 {
+				return false
+			}
+		}
+		return true
+	})
+	cancel()
+	wg.Wait()
+
+	select {
+	case err := <-coordDone:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("coordinator did not see all nodes finish")
+	}
+
+	for i, node := range ring {
+		if _, rejected := node.Migrants(); i > 0 && rejected != 0 {
+			t.Errorf("node %d rejected %d migrants of the right length", i, rejected)
+		}
+	}
+	statuses := coordinator.Statuses()
+	if len(statuses) != nodes {
+		t.Fatalf("coordinator knows %d nodes, want %d", len(statuses), nodes)
+	}
+	globalBest := 0
+	for _, b := range best {
+		globalBest = max(globalBest, b.Fitness)
+	}
+	got, ok := coordinator.Best()
+	if !ok || got.Best.Fitness != globalBest {
+		t.Errorf("coordinator best = %d (ok=%v), want %d", got.Best.Fitness, ok, globalBest)
+	}
+	if len(got.Best.Genes) != len(ring[0].GA.Graph.Edges) {
+		t.Errorf("coordinator best has %d genes, want %d", len(got.Best.Genes), len(ring[0].GA.Graph.Edges))
+	}
+}
+
+// TestIslandNodeKeepsMigrants проверяет, что мигранты попадают на острова островной модели,
+// из которых она собирает популяцию, а не только в общую популяцию
+func TestIslandNodeKeepsMigrants(t *testing.T) {
+	graph := genetic.PredefinedGraphs()["Great grid 25x40 (1000)"].ToGraph()
+	ga, err := genetic.NewGeneticAlgorithm(
+		&graph,
+		genetic.Island,
+		&genetic.SinglePoint{},
+		&genetic.TournamentSelectionStrategy{TournamentSize: 3},
+		&genetic.ClassicMutationStrategy{},
+		20, 10, 0.01, 0.9, 2, 5,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := NewNode(NodeConfig{ListenAddr: "127.0.0.1:0", MigrationInterval: 5, Migration: genetic.MigrationPolicy{Migrants: 2}}, ga)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ga.InitializePopulation()
+	ga.ResetIslands()
+
+	migrant := FromChromosome(ga.GenerateChromosome())
+	node.handle(Message{Type: MsgMigrants, Node: 1, Migrants: []Genome{migrant, migrant}})
+	node.acceptInbox()
+
+	found := 0
+	for _, island := range ga.Islands {
+		for _, c := range island {
+			if slices.Equal(c.Genes, migrant.Genes) {
+				found++
+			}
+		}
+	}
+	if found < 2 {
+		t.Errorf("found %d copies of the migrant on islands, want at least 2", found)
+	}
+	if len(ga.Population) != 20 {
+		t.Errorf("population has %d individuals after migration, want 20", len(ga.Population))
+	}
+}
@@ -0,0 +1,220 @@
+package distributed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"Genetic-algorithm/backend/genetic"
+)
+
+// NodeConfig содержит настройки узла-острова
+type NodeConfig struct {
+	ID                int                     // Номер узла
+	ListenAddr        string                  // Адрес для приёма мигрантов, например "127.0.0.1:7001"
+	Neighbors         []string                // Адреса соседей, которым отправляются мигранты
+	CoordinatorAddr   string                  // Адрес координатора (пусто — без отчётов)
+	MigrationInterval int                     // Число поколений между отправками мигрантов
+	Migration         genetic.MigrationPolicy // Выбор эмигрантов и вытесняемых особей
+}
+
+// Node запускает один остров в отдельном процессе и обменивается мигрантами с соседями.
+// Миграция асинхронная: входящие мигранты принимаются в начале ближайшего поколения.
+type Node struct {
+	Config NodeConfig
+	GA     *genetic.Algorithm
+
+	listener net.Listener
+
+	mu       sync.Mutex
+	inbox    []genetic.Chromosome
+	accepted int // Мигрантов принято в популяцию
+	rejected int // Мигрантов отклонено из-за длины генома
+}
+
+// NewNode создаёт узел для уже сконфигурированного алгоритма
+func NewNode(cfg NodeConfig, ga *genetic.Algorithm) (*Node, error) {
+	if ga == nil {
+		return nil, errors.New("node requires an algorithm")
+	}
+	if cfg.ListenAddr == "" {
+		return nil, errors.New("node listen address is required")
+	}
+	if cfg.MigrationInterval < 1 {
+		return nil, errors.New("migration interval must be at least 1")
+	}
+	return &Node{Config: cfg, GA: ga}, nil
+}
+
+// Listen открывает адрес приёма мигрантов и начинает их принимать; возвращает фактический
+// адрес (при порте 0 он становится известен только здесь). Run вызывает Listen сам,
+// если узел ещё не слушает
+func (n *Node) Listen() (string, error) {
+	if n.listener == nil {
+		listener, err := net.Listen("tcp", n.Config.ListenAddr)
+		if err != nil {
+			return "", err
+		}
+		n.listener = listener
+		go serve(listener, n.handle)
+	}
+	return n.listener.Addr().String(), nil
+}
+
+// Run выполняет эволюцию до завершения алгоритма или отмены ctx.
+// Возвращает лучшую найденную хромосому.
+func (n *Node) Run(ctx context.Context) (genetic.Chromosome, error) {
+	addr, err := n.Listen()
+	if err != nil {
+		return genetic.Chromosome{}, err
+	}
+	defer func() {
+		n.listener.Close()
+		n.listener = nil
+	}()
+
+	ga := n.GA
+	ga.Logger.LogMilestone("Узел %d слушает %s, соседи: %v", n.Config.ID, addr, n.Config.Neighbors)
+	ga.InitializePopulation()
+
+	for !ga.ShouldTerminate() {
+		if err := ctx.Err(); err != nil {
+			break
+		}
+
+		n.acceptInbox()
+		if err := ga.EvolutionModel.Evolve(ga); err != nil {
+			return ga.GetBestSoFar(), err
+		}
+		current := ga.GetBestChromosome()
+		ga.SetLocalBest(current)
+		if ga.IsBetterThanBestSoFar(current) {
+			n.repairAndEvaluate(&current)
+			ga.SetBestSoFar(current)
+		}
+
+		if ga.CurrentGeneration%n.Config.MigrationInterval == 0 {
+			n.emigrate()
+			n.report(false)
+		}
+	}
+
+	n.report(true)
+	return ga.GetBestSoFar(), nil
+}
+
+// handle обрабатывает входящее сообщение
+func (n *Node) handle(msg Message) {
+	if msg.Type != MsgMigrants {
+		return
+	}
+	length := n.GA.Problem.GenomeLength()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, g := range msg.Migrants {
+		// Геном другой длины означает, что сосед решает другую задачу
+		if len(g.Genes) != length {
+			n.GA.Logger.LogWarning("Узел %d: отклонён мигрант от узла %d (длина %d, ожидалась %d)",
+				n.Config.ID, msg.Node, len(g.Genes), length)
+			n.rejected++
+			continue
+		}
+		n.inbox = append(n.inbox, g.ToChromosome())
+	}
+}
+
+// acceptInbox переносит накопившихся мигрантов в популяцию
+func (n *Node) acceptInbox() {
+	n.mu.Lock()
+	migrants := n.inbox
+	n.inbox = nil
+	n.accepted += len(migrants)
+	n.mu.Unlock()
+	if len(migrants) == 0 {
+		return
+	}
+
+	// Фитнес от соседей не доверяем: чиним и пересчитываем
+	for i := range migrants {
+		n.repairAndEvaluate(&migrants[i])
+	}
+	islands := n.GA.Islands
+	if len(islands) == 0 {
+		genetic.AcceptImmigrants(n.GA.Population, migrants, n.Config.Migration)
+	} else {
+		// Островная модель эволюционирует острова, а не общую популяцию: мигранты
+		// распределяются по островам по кругу, популяция собирается заново
+		shares := make([][]genetic.Chromosome, len(islands))
+		for i, m := range migrants {
+			shares[i%len(islands)] = append(shares[i%len(islands)], m)
+		}
+		for i := range islands {
+			genetic.AcceptImmigrants(islands[i], shares[i], n.Config.Migration)
+		}
+		n.GA.Population = genetic.MergeIslands(islands)
+	}
+	n.GA.Logger.LogInfo("Узел %d: принято мигрантов: %d", n.Config.ID, len(migrants))
+}
+
+// repairAndEvaluate чинит и оценивает хромосому задачей узла; паросочетание чинится
+// детерминированно, как в GASolver
+func (n *Node) repairAndEvaluate(chrom *genetic.Chromosome) {
+	if n.GA.Problem.Type() == genetic.Matching {
+		genetic.RepairFast(chrom, n.GA.Graph)
+	} else {
+		n.GA.Problem.Repair(chrom)
+	}
+	n.GA.Problem.Evaluate(chrom)
+}
+
+// Migrants возвращает число принятых в популяцию и отклонённых мигрантов
+func (n *Node) Migrants() (accepted, rejected int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.accepted, n.rejected
+}
+
+// emigrate отправляет мигрантов всем соседям
+func (n *Node) emigrate() {
+	emigrants := genetic.SelectEmigrants(n.GA.Population, n.Config.Migration)
+	msg := Message{
+		Type:       MsgMigrants,
+		Node:       n.Config.ID,
+		Generation: n.GA.CurrentGeneration,
+		Migrants:   make([]Genome, len(emigrants)),
+	}
+	for i, c := range emigrants {
+		msg.Migrants[i] = FromChromosome(c)
+	}
+	for _, addr := range n.Config.Neighbors {
+		// Недоступный сосед не останавливает эволюцию
+		if err := Send(addr, msg); err != nil {
+			n.GA.Logger.LogWarning("Узел %d: не удалось отправить мигрантов на %s: %v", n.Config.ID, addr, err)
+		}
+	}
+}
+
+// report отправляет координатору лучшее решение узла
+func (n *Node) report(done bool) {
+	if n.Config.CoordinatorAddr == "" {
+		return
+	}
+	best := FromChromosome(n.GA.GetBestSoFar())
+	msg := Message{
+		Type:       MsgReport,
+		Node:       n.Config.ID,
+		Generation: n.GA.CurrentGeneration,
+		Best:       &best,
+		Done:       done,
+	}
+	if err := Send(n.Config.CoordinatorAddr, msg); err != nil {
+		n.GA.Logger.LogWarning("Узел %d: координатор недоступен: %v", n.Config.ID, err)
+	}
+}
+
+// String возвращает краткое описание узла
+func (n *Node) String() string {
+	return fmt.Sprintf("node %d (%s)", n.Config.ID, n.Config.ListenAddr)
+}
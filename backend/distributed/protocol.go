@@ -0,0 +1,86 @@
+package distributed
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"Genetic-algorithm/backend/genetic"
+)
+
+// Протокол — JSON-сообщения, разделённые переводом строки, поверх TCP.
+// Каждое соединение открывается отправителем, передаёт одно или несколько
+// сообщений и закрывается.
+
+// MessageType определяет тип сообщения протокола
+type MessageType string
+
+const (
+	MsgMigrants MessageType = "migrants" // Мигранты от соседнего узла
+	MsgReport   MessageType = "report"   // Отчёт узла координатору
+)
+
+// dialTimeout ограничивает время установления соединения с соседом
+const dialTimeout = 2 * time.Second
+
+// Genome — сериализуемое представление хромосомы
+type Genome struct {
+	Genes   []bool `json:"genes"`
+	Fitness int    `json:"fitness"`
+}
+
+// Message — конверт протокола
+type Message struct {
+	Type       MessageType `json:"type"`
+	Node       int         `json:"node"`
+	Generation int         `json:"generation"`
+	Migrants   []Genome    `json:"migrants,omitempty"` // Для MsgMigrants
+	Best       *Genome     `json:"best,omitempty"`     // Для MsgReport
+	Done       bool        `json:"done,omitempty"`     // Узел завершил работу
+}
+
+// FromChromosome преобразует хромосому в Genome
+func FromChromosome(chrom genetic.Chromosome) Genome {
+	genes := make([]bool, len(chrom.Genes))
+	copy(genes, chrom.Genes)
+	return Genome{Genes: genes, Fitness: chrom.Fitness}
+}
+
+// ToChromosome преобразует Genome в хромосому
+func (g Genome) ToChromosome() genetic.Chromosome {
+	genes := make([]bool, len(g.Genes))
+	copy(genes, g.Genes)
+	return genetic.Chromosome{Genes: genes, Fitness: g.Fitness}
+}
+
+// Send устанавливает соединение с addr и отправляет одно сообщение
+func Send(addr string, msg Message) error {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return json.NewEncoder(conn).Encode(msg)
+}
+
+// serve принимает соединения на listener и передаёт прочитанные сообщения в handle.
+// Возвращается после закрытия listener.
+func serve(listener net.Listener, handle func(Message)) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func(conn net.Conn) {
+			defer conn.Close()
+			dec := json.NewDecoder(conn)
+			for {
+				var msg Message
+				if err := dec.Decode(&msg); err != nil {
+					return
+				}
+				handle(msg)
+			}
+		}(conn)
+	}
+}
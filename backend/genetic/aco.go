@@ -0,0 +1,339 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Муравьиный алгоритм для паросочетания: каждый муравей строит паросочетание, добавляя
+// рёбра по одному с вероятностью, пропорциональной τ^Alpha · η^Beta, где τ — феромон ребра,
+// а η — эвристика, предпочитающая рёбра между вершинами малой степени. Популяция
+// поколения — паросочетания муравьёв; феромон испаряется и откладывается по их качеству.
+
+// ------------------------ Настройки ------------------------ //
+
+// PheromoneUpdate определяет правило отложения феромона
+type PheromoneUpdate int
+
+const (
+	// ElitistAntSystem — феромон откладывают все муравьи, лучшее найденное решение — с весом ElitistWeight
+	ElitistAntSystem PheromoneUpdate = iota
+	// MaxMinAntSystem — откладывает только лучший муравей, феромон ограничен [τmin, τmax]
+	MaxMinAntSystem
+)
+
+func (u PheromoneUpdate) String() string {
+	switch u {
+	case ElitistAntSystem:
+		return "Elitist"
+	case MaxMinAntSystem:
+		return "MaxMin"
+	default:
+		return "Unknown"
+	}
+}
+
+// maxMinGlobalInterval — в MAX-MIN каждое такое поколение феромон откладывает лучшее
+// найденное решение, в остальные — лучший муравей поколения
+const maxMinGlobalInterval = 5
+
+// ACOConfig задаёт муравьиный алгоритм; число муравьёв равно PopulationSize
+type ACOConfig struct {
+	Update        PheromoneUpdate
+	Alpha         float64 // Вес феромона (0 — 1)
+	Beta          float64 // Вес эвристики степеней (0 — 2)
+	Evaporation   float64 // Доля феромона, испаряющаяся за поколение (0 — 0.1)
+	ElitistWeight float64 // Вес лучшего решения в элитной системе (0 — число муравьёв)
+	LocalSearch   bool    // Улучшать муравьёв локальным поиском меметической модели
+}
+
+// Validate проверяет настройки
+func (c ACOConfig) Validate() error {
+	if c.Alpha < 0 || c.Beta < 0 {
+		return fmt.Errorf("pheromone and heuristic weights must not be negative, got %v and %v", c.Alpha, c.Beta)
+	}
+	if c.Evaporation < 0 || c.Evaporation >= 1 {
+		return fmt.Errorf("evaporation rate must be in [0, 1), got %v", c.Evaporation)
+	}
+	if c.ElitistWeight < 0 {
+		return fmt.Errorf("elitist weight must not be negative, got %v", c.ElitistWeight)
+	}
+	return nil
+}
+
+// ------------------------ Модель ------------------------ //
+
+// ACOModel реализует элитную и MAX-MIN муравьиные системы
+type ACOModel struct {
+	Config ACOConfig
+
+	pheromone []float64 // Феромон на рёбрах
+	heuristic []float64 // η ребра
+	best      Chromosome
+}
+
+// minPheromone не даёт феромону элитной системы обнулиться и исключить ребро навсегда
+const minPheromone = 1e-6
+
+func (m *ACOModel) evaporation() float64 {
+	if m.Config.Evaporation == 0 {
+		return 0.1
+	}
+	return m.Config.Evaporation
+}
+
+// bounds возвращает τmin и τmax системы MAX-MIN: τmax — предел, к которому сходится феромон
+// ребра, получающего единичное отложение каждое поколение
+func (m *ACOModel) bounds() (lo, hi float64) {
+	hi = 1 / m.evaporation()
+	return hi / float64(2*max(len(m.pheromone), 1)), hi
+}
+
+// prepare вычисляет эвристику и начальный феромон
+func (m *ACOModel) prepare(graph *Graph) {
+	degree := make([]int, graph.NumVertices)
+	for _, e := range graph.Edges {
+		degree[e.U]++
+		degree[e.V]++
+	}
+	// Ребро между вершинами малой (относительно ёмкости) степени редко мешает другим рёбрам
+	m.heuristic = make([]float64, len(graph.Edges))
+	for i, e := range graph.Edges {
+		load := float64(degree[e.U])/float64(max(graph.Capacity(e.U), 1)) +
+			float64(degree[e.V])/float64(max(graph.Capacity(e.V), 1))
+		m.heuristic[i] = 1 / load
+	}
+	m.pheromone = make([]float64, len(graph.Edges))
+	m.resetPheromone()
+}
+
+// resetPheromone возвращает феромон к начальному уровню: τmax для MAX-MIN, 1 для элитной системы
+func (m *ACOModel) resetPheromone() {
+	level := 1.0
+	if m.Config.Update == MaxMinAntSystem {
+		_, level = m.bounds()
+	}
+	for i := range m.pheromone {
+		m.pheromone[i] = level
+	}
+}
+
+// resetSlots вызывается, когда перезапуск заменил муравьёв поколения случайными решениями:
+// феромон возвращается к начальному уровню, иначе муравьи построят прежние решения.
+// В элитной системе следы оставленных особей (элиты частичного перезапуска, не вытесненных
+// иммигрантами) откладываются заново; в MAX-MIN начальный уровень и так равен τmax,
+// а лучшее решение продолжает откладываться из m.best
+func (m *ACOModel) resetSlots(ga *Algorithm, slots []int) {
+	if len(m.pheromone) == 0 {
+		return
+	}
+	m.resetPheromone()
+	if m.Config.Update == MaxMinAntSystem {
+		return
+	}
+	replaced := make([]bool, len(ga.Population))
+	for _, idx := range slots {
+		replaced[idx] = true
+	}
+	for i, c := range ga.Population {
+		if !replaced[i] {
+			m.deposit(c, 1)
+		}
+	}
+}
+
+// construct строит паросочетание одного муравья
+func (m *ACOModel) construct(graph *Graph, weight []float64) Chromosome {
+	ant := Chromosome{Genes: make([]bool, len(graph.Edges))}
+	load := newVertexLoad(graph)
+	candidates := make([]int, 0, len(graph.Edges))
+	for i, e := range graph.Edges {
+		if load.fits(e) {
+			candidates = append(candidates, i)
+		}
+	}
+
+	for len(candidates) > 0 {
+		total := 0.0
+		for _, i := range candidates {
+			total += weight[i]
+		}
+		chosen := candidates[len(candidates)-1]
+		r := rng.Float64() * total
+		for _, i := range candidates {
+			r -= weight[i]
+			if r < 0 {
+				chosen = i
+				break
+			}
+		}
+		ant.Genes[chosen] = true
+		load.add(graph.Edges[chosen])
+
+		// Рёбра у насыщенных концов выбывают из кандидатов
+		kept := candidates[:0]
+		for _, i := range candidates {
+			if i != chosen && load.fits(graph.Edges[i]) {
+				kept = append(kept, i)
+			}
+		}
+		candidates = kept
+	}
+	return ant
+}
+
+func (m *ACOModel) Evolve(ga *Algorithm) error {
+	if len(ga.Population) == 0 {
+		return errors.New("empty population")
+	}
+	if len(m.pheromone) != len(ga.Graph.Edges) {
+		m.prepare(ga.Graph)
+		m.best = copyChromosome(ga.GetBestChromosome())
+	}
+
+	alpha, beta := m.Config.Alpha, m.Config.Beta
+	if alpha == 0 {
+		alpha = 1
+	}
+	if beta == 0 {
+		beta = 2
+	}
+	weight := make([]float64, len(m.pheromone))
+	for i, tau := range m.pheromone {
+		weight[i] = math.Pow(tau, alpha) * math.Pow(m.heuristic[i], beta)
+	}
+
+	ants := make([]Chromosome, ga.PopulationSize)
+	iterationBest := 0
+	for k := range ants {
+		ant := m.construct(ga.Graph, weight)
+		ga.repair(&ant)
+		ga.evaluate(&ant)
+		if m.Config.LocalSearch {
+			ga.applyLocalSearch(&ant)
+		}
+		ants[k] = ant
+		if ant.Fitness > ants[iterationBest].Fitness {
+			iterationBest = k
+		}
+	}
+	if ants[iterationBest].Fitness > m.best.Fitness {
+		m.best = copyChromosome(ants[iterationBest])
+	}
+
+	m.updatePheromone(ga, ants, ants[iterationBest])
+
+	ga.Population = ants
+	ga.SetBestSoFar(m.best)
+	ga.SetLocalBest(ants[iterationBest])
+	ga.adaptRates()
+	ga.checkStagnation()
+	ga.CurrentGeneration++
+	ga.Logger.LogGeneration(ga)
+	lo, hi := m.pheromoneRange()
+	ga.Logger.LogInfo("Феромон %v: от %.4f до %.4f", m.Config.Update, lo, hi)
+
+	if ga.ShouldTerminate() {
+		ga.Logger.LogCompletion(ga)
+	}
+
+	return nil
+}
+
+// updatePheromone испаряет феромон и откладывает его по правилу Config.Update
+func (m *ACOModel) updatePheromone(ga *Algorithm, ants []Chromosome, iterationBest Chromosome) {
+	keep := 1 - m.evaporation()
+	for i := range m.pheromone {
+		m.pheromone[i] *= keep
+	}
+
+	if m.Config.Update == MaxMinAntSystem {
+		deposit := iterationBest
+		if ga.CurrentGeneration%maxMinGlobalInterval == 0 {
+			deposit = m.best
+		}
+		m.deposit(deposit, 1)
+		lo, hi := m.bounds()
+		for i, tau := range m.pheromone {
+			m.pheromone[i] = math.Min(math.Max(tau, lo), hi)
+		}
+		return
+	}
+
+	// Элитная система: вклад муравья растёт от 0 у худшего в поколении до 1 у лучшего найденного решения
+	worst := ants[0].Fitness
+	for _, ant := range ants {
+		worst = min(worst, ant.Fitness)
+	}
+	span := float64(m.best.Fitness - worst)
+	for _, ant := range ants {
+		quality := 1.0
+		if span > 0 {
+			quality = float64(ant.Fitness-worst) / span
+		}
+		m.deposit(ant, quality)
+	}
+	elitist := m.Config.ElitistWeight
+	if elitist == 0 {
+		elitist = float64(len(ants))
+	}
+	m.deposit(m.best, elitist)
+	for i, tau := range m.pheromone {
+		m.pheromone[i] = math.Max(tau, minPheromone)
+	}
+}
+
+// deposit добавляет amount феромона на рёбра решения
+func (m *ACOModel) deposit(c Chromosome, amount float64) {
+	for i, on := range c.Genes {
+		if on {
+			m.pheromone[i] += amount
+		}
+	}
+}
+
+func (m *ACOModel) pheromoneRange() (lo, hi float64) {
+	if len(m.pheromone) == 0 {
+		return 0, 0
+	}
+	lo, hi = m.pheromone[0], m.pheromone[0]
+	for _, tau := range m.pheromone {
+		lo, hi = math.Min(lo, tau), math.Max(hi, tau)
+	}
+	return lo, hi
+}
+
+func (m *ACOModel) GetRequiredStrategies() []string {
+	return nil
+}
+
+func (m *ACOModel) ValidateStrategies(ga *Algorithm) error {
+	if ga.Problem.Type() != Matching {
+		return fmt.Errorf("ant colony builds matchings, not solutions of %v", ga.Problem.Type())
+	}
+	return m.Config.Validate()
+}
+
+func (m *ACOModel) GetModelName() string {
+	return "ACO"
+}
+
+func (m *ACOModel) String() string {
+	return "ACO"
+}
+
+// PheromoneLevels возвращает феромон на рёбрах, нормированный на наибольшее значение,
+// и признак того, что модель — муравьиный алгоритм
+func (ga *Algorithm) PheromoneLevels() ([]float64, bool) {
+	m, ok := ga.EvolutionModel.(*ACOModel)
+	if !ok || m.pheromone == nil {
+		return nil, false
+	}
+	_, hi := m.pheromoneRange()
+	levels := make([]float64, len(m.pheromone))
+	for i, tau := range m.pheromone {
+		levels[i] = tau / hi
+	}
+	return levels, true
+}
@@ -0,0 +1,354 @@
+package genetic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Задача о назначениях: на взвешенном двудольном графе (Graph.Costs != nil) алгоритм
+// минимизирует суммарную стоимость среди паросочетаний наибольшей мощности.
+// Приспособленность лексикографическая: сначала число рёбер, затем меньшая стоимость.
+
+// costScale — число единиц приспособленности на единицу стоимости (точность 0.001)
+const costScale = 1000
+
+// scaledCost переводит стоимость ребра в целые единицы приспособленности
+func scaledCost(c float64) int {
+	return int(math.Round(c * costScale))
+}
+
+// cardinalityWeight возвращает вес одного ребра паросочетания: он больше суммарной
+// стоимости любых рёбер, поэтому лишнее ребро всегда выгоднее экономии стоимости
+func cardinalityWeight(graph *Graph) int {
+	total := 1
+	for _, c := range graph.Costs {
+		total += scaledCost(c)
+	}
+	return total
+}
+
+// AssignmentSolution — паросочетание взвешенного графа и его характеристики
+type AssignmentSolution struct {
+	Genes []bool
+	Size  int     // Число рёбер
+	Cost  float64 // Суммарная стоимость рёбер
+}
+
+// MatchingCost возвращает число рёбер и стоимость допустимой части паросочетания genes
+func MatchingCost(genes []bool, graph *Graph) (int, float64) {
+	valid := ValidMatchingEdges(genes, graph)
+	cost := 0.0
+	if graph.Costs != nil {
+		for _, i := range valid {
+			cost += graph.Costs[i]
+		}
+	}
+	return len(valid), cost
+}
+
+// CostGap возвращает относительный разрыв стоимости найденного решения и оптимума.
+// При нулевой стоимости оптимума относительный разрыв не определён и возвращается 0:
+// разрыв тогда описывает абсолютная разность cost − optimal
+func CostGap(cost, optimal float64) float64 {
+	if optimal == 0 {
+		return 0
+	}
+	return (cost - optimal) / math.Abs(optimal)
+}
+
+// validateCosts проверяет стоимости рёбер взвешенного графа
+func validateCosts(graph *Graph) error {
+	if graph.Costs == nil {
+		if graph.RequirePerfect {
+			return errors.New("perfect matching mode requires edge costs")
+		}
+		return nil
+	}
+	if len(graph.Costs) != len(graph.Edges) {
+		return fmt.Errorf("graph has %d costs for %d edges", len(graph.Costs), len(graph.Edges))
+	}
+	for i, c := range graph.Costs {
+		if c < 0 || math.IsNaN(c) || math.IsInf(c, 0) {
+			return fmt.Errorf("edge %d has invalid cost %v: costs must be finite and non-negative", i, c)
+		}
+	}
+	return nil
+}
+
+// ------------------------ Венгерский алгоритм ------------------------ //
+
+// bipartition раскрашивает граф в две доли; возвращает номера вершин левой и правой долей
+func bipartition(graph *Graph) (left, right []int, err error) {
+	adj := make([][]int, graph.NumVertices)
+	for _, e := range graph.Edges {
+		adj[e.U] = append(adj[e.U], e.V)
+		adj[e.V] = append(adj[e.V], e.U)
+	}
+	side := make([]int, graph.NumVertices)
+	for i := range side {
+		side[i] = -1
+	}
+	for start := range side {
+		if side[start] >= 0 {
+			continue
+		}
+		side[start] = 0
+		queue := []int{start}
+		for qi := 0; qi < len(queue); qi++ {
+			v := queue[qi]
+			for _, w := range adj[v] {
+				if side[w] < 0 {
+					side[w] = 1 - side[v]
+					queue = append(queue, w)
+				} else if side[w] == side[v] {
+					return nil, nil, fmt.Errorf("graph is not bipartite: odd cycle through edge %d-%d", v, w)
+				}
+			}
+		}
+	}
+	for v, s := range side {
+		if s == 0 {
+			left = append(left, v)
+		} else {
+			right = append(right, v)
+		}
+	}
+	return left, right, nil
+}
+
+// SolveAssignment находит паросочетание наибольшей мощности минимальной стоимости
+// венгерским алгоритмом за O(n³). Граф должен быть двудольным и иметь стоимости рёбер.
+func SolveAssignment(graph *Graph) (AssignmentSolution, error) {
+	if graph.Costs == nil {
+		return AssignmentSolution{}, errors.New("assignment requires edge costs")
+	}
+	if err := validateCosts(graph); err != nil {
+		return AssignmentSolution{}, err
+	}
+	if err := validateCapacities(graph); err != nil {
+		return AssignmentSolution{}, err
+	}
+	left, right, err := bipartition(graph)
+	if err != nil {
+		return AssignmentSolution{}, err
+	}
+
+	n := len(left)
+	if len(right) > n {
+		n = len(right)
+	}
+	row := make(map[int]int, len(left))
+	for i, v := range left {
+		row[v] = i
+	}
+	col := make(map[int]int, len(right))
+	for j, v := range right {
+		col[v] = j
+	}
+
+	// Отсутствующие рёбра и фиктивные строки/столбцы стоят дороже любого набора настоящих рёбер,
+	// поэтому оптимум сначала максимизирует число настоящих рёбер
+	big := 1.0
+	for _, c := range graph.Costs {
+		big += c
+	}
+	big *= 2
+	cost := make([][]float64, n)
+	edge := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]float64, n)
+		edge[i] = make([]int, n)
+		for j := range cost[i] {
+			cost[i][j] = big
+			edge[i][j] = -1
+		}
+	}
+	for idx, e := range graph.Edges {
+		u, v := e.U, e.V
+		if _, ok := row[u]; !ok {
+			u, v = v, u
+		}
+		i, j := row[u], col[v]
+		if edge[i][j] < 0 || graph.Costs[idx] < cost[i][j] {
+			cost[i][j] = graph.Costs[idx]
+			edge[i][j] = idx
+		}
+	}
+
+	match := hungarian(cost)
+	sol := AssignmentSolution{Genes: make([]bool, len(graph.Edges))}
+	for i, j := range match {
+		if idx := edge[i][j]; idx >= 0 {
+			sol.Genes[idx] = true
+			sol.Size++
+			sol.Cost += graph.Costs[idx]
+		}
+	}
+	if graph.RequirePerfect && 2*sol.Size != graph.NumVertices {
+		return sol, fmt.Errorf("graph has no perfect matching: maximum matching covers %d of %d vertices",
+			2*sol.Size, graph.NumVertices)
+	}
+	return sol, nil
+}
+
+// hungarian решает задачу о назначениях для квадратной матрицы стоимостей
+// методом потенциалов и возвращает столбец, назначенный каждой строке
+func hungarian(a [][]float64) []int {
+	n := len(a)
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1) // p[j] — строка (с 1), назначенная столбцу j
+	way := make([]int, n+1)
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := a[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	match := make([]int, n)
+	for j := 1; j <= n; j++ {
+		match[p[j]-1] = j - 1
+	}
+	return match
+}
+
+// ------------------------ Матрица стоимостей ------------------------ //
+
+// NewAssignmentModel строит двудольный граф по матрице стоимостей: строки — левая доля,
+// столбцы — правая. Значения NaN и ±Inf означают отсутствие ребра.
+func NewAssignmentModel(costs [][]float64) (*GraphModel, error) {
+	rows := len(costs)
+	if rows == 0 {
+		return nil, errors.New("cost matrix is empty")
+	}
+	cols := len(costs[0])
+	for i, r := range costs {
+		if len(r) != cols {
+			return nil, fmt.Errorf("cost matrix row %d has %d columns, expected %d", i, len(r), cols)
+		}
+	}
+
+	gm := newBipartiteModel(rows, cols)
+	for i, r := range costs {
+		for j, c := range r {
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				continue
+			}
+			if c < 0 {
+				return nil, fmt.Errorf("cost[%d][%d] = %v: costs must be non-negative", i, j, c)
+			}
+			gm.AddWeightedEdge(i, rows+j, c)
+		}
+	}
+	return gm, nil
+}
+
+// newBipartiteModel создаёт граф без рёбер с вершинами 0..rows-1 в левой доле и rows..rows+cols-1 в правой;
+// доли рисуются двумя столбцами
+func newBipartiteModel(rows, cols int) *GraphModel {
+	gm := NewGraphModel(rows + cols)
+	place := func(v, k, total int, x float64) {
+		gm.Positions[v] = Point2D{X: x, Y: 50 + 500*float64(k+1)/float64(total+1)}
+	}
+	for i := 0; i < rows; i++ {
+		place(i, i, rows, 200)
+	}
+	for j := 0; j < cols; j++ {
+		place(rows+j, j, cols, 600)
+	}
+	return gm
+}
+
+// LoadCostMatrix читает матрицу стоимостей из текстового файла: строка файла — строка матрицы,
+// значения разделены пробелами, запятыми или точками с запятой; "-", "x" и "inf" — нет ребра
+func LoadCostMatrix(path string) ([][]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var costs [][]float64
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ',' || r == ';'
+		})
+		row := make([]float64, len(fields))
+		for k, field := range fields {
+			switch strings.ToLower(field) {
+			case "-", "x", "inf":
+				row[k] = math.Inf(1)
+				continue
+			}
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			row[k] = v
+		}
+		costs = append(costs, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return costs, nil
+}
+
+// AssignmentReference возвращает оптимальное назначение, вычисленное при создании алгоритма,
+// и признак того, что граф взвешенный
+func (ga *Algorithm) AssignmentReference() (AssignmentSolution, bool) {
+	p, ok := ga.Problem.(*MatchingProblem)
+	if !ok || p.reference == nil {
+		return AssignmentSolution{}, false
+	}
+	return *p.reference, true
+}
@@ -2,67 +2,479 @@ package genetic
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
-// NewGeneticAlgorithm создаёт экземпляр алгоритма с заданными параметрами.
-func NewGeneticAlgorithm(
-	graph *Graph,
-	evolutionModel EvolutionModel,
-	crossoverStrategy CrossoverStrategy,
-	selectionStrategy SelectionStrategy,
-	mutationStrategy MutationStrategy,
-	populationSize, generations int,
-	mutationRate, crossoverRate float64,
-	numIslands, migrationInterval int,
-) (*Algorithm, error) {
-	if populationSize < 1 {
-		return nil, errors.New("populationSize must be ≥ 1")
+// Settings содержит все параметры, из которых New собирает алгоритм.
+// Заполняется опциями; незаданные поля получают значения по умолчанию.
+type Settings struct {
+	Model   EvolutionModelConfig // Модель эволюции и её механизмы
+	Config  Config               // Размер популяции, число поколений, вероятность мутации, починка, кэш
+	Problem ProblemType          // Решаемая задача (по умолчанию — паросочетание)
+
+	Selection SelectionStrategy
+	Crossover CrossoverStrategy
+	Mutation  MutationStrategy
+
+	CrossoverRate      float64
+	EliteSize          int  // Число элитных особей (0 — 10% популяции, не меньше 1)
+	NumIslands         int  // Для островной модели
+	MigrationInterval  int  // Число поколений между миграциями
+	OptimalTermination bool // Останавливаться при достижении наибольшего паросочетания
+
+	RateControl RateControlConfig // Расписания и адаптация вероятностей мутации и кроссовера
+	Stagnation  StagnationConfig  // Обнаружение стагнации и перезапуски
+}
+
+// Option изменяет настройки алгоритма при создании
+type Option func(*Settings)
+
+// DefaultSettings возвращает настройки по умолчанию (совпадают с настройками GUI)
+func DefaultSettings() Settings {
+	return Settings{
+		Model: EvolutionModelConfig{
+			Model:        Classic,
+			UseSelection: true,
+			UseCrossover: true,
+			UseMutation:  true,
+		},
+		Config: Config{
+			UseFastRepair:  true,
+			PopulationSize: 100,
+			MutationRate:   0.05,
+			Generations:    100,
+		},
+		Selection:         &TournamentSelectionStrategy{TournamentSize: 3},
+		Crossover:         &SinglePoint{},
+		Mutation:          &ClassicMutationStrategy{},
+		CrossoverRate:     0.8,
+		NumIslands:        1,
+		MigrationInterval: 10,
+	}
+}
+
+// NewSettings возвращает DefaultSettings, изменённые опциями
+func NewSettings(opts ...Option) Settings {
+	s := DefaultSettings()
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// WithModel выбирает модель эволюции
+func WithModel(model EvolutionModel) Option {
+	return func(s *Settings) {
+		s.Model.Model = model
+		s.Model.UseLocalSearch = model == Memetic
+	}
+}
+
+// WithCombinedStages включает и выключает этапы комбинированной модели
+func WithCombinedStages(selection, crossover, mutation, localSearch bool) Option {
+	return func(s *Settings) {
+		s.Model.UseSelection = selection
+		s.Model.UseCrossover = crossover
+		s.Model.UseMutation = mutation
+		s.Model.UseLocalSearch = localSearch
+	}
+}
+
+// WithOperators задаёт операторы селекции, кроссовера и мутации
+func WithOperators(selection SelectionStrategy, crossover CrossoverStrategy, mutation MutationStrategy) Option {
+	return func(s *Settings) {
+		s.Selection = selection
+		s.Crossover = crossover
+		s.Mutation = mutation
+	}
+}
+
+// WithPopulation задаёт размер популяции и число поколений
+func WithPopulation(size, generations int) Option {
+	return func(s *Settings) {
+		s.Config.PopulationSize = size
+		s.Config.Generations = generations
+	}
+}
+
+// WithRates задаёт вероятности мутации и кроссовера
+func WithRates(mutation, crossover float64) Option {
+	return func(s *Settings) {
+		s.Config.MutationRate = mutation
+		s.CrossoverRate = crossover
+	}
+}
+
+// WithEliteSize задаёт число элитных особей
+func WithEliteSize(n int) Option {
+	return func(s *Settings) {
+		s.EliteSize = n
+	}
+}
+
+// WithIslandCount задаёт число островов и интервал миграции
+func WithIslandCount(numIslands, migrationInterval int) Option {
+	return func(s *Settings) {
+		s.NumIslands = numIslands
+		s.MigrationInterval = migrationInterval
 	}
-	if mutationRate < 0 || mutationRate > 1 {
-		return nil, errors.New("mutationRate must be in [0, 1]")
+}
+
+// WithConfig задаёт Config целиком. Нулевые PopulationSize, Generations и MutationRate
+// не затирают уже заданные значения.
+func WithConfig(c Config) Option {
+	return func(s *Settings) {
+		if c.PopulationSize == 0 {
+			c.PopulationSize = s.Config.PopulationSize
+		}
+		if c.Generations == 0 {
+			c.Generations = s.Config.Generations
+		}
+		if c.MutationRate == 0 {
+			c.MutationRate = s.Config.MutationRate
+		}
+		s.Config = c
+	}
+}
+
+// WithOptimalTermination включает остановку при достижении наибольшего паросочетания
+func WithOptimalTermination(enabled bool) Option {
+	return func(s *Settings) {
+		s.OptimalTermination = enabled
+	}
+}
+
+// WithDiversity включает механизмы сохранения разнообразия
+func WithDiversity(d DiversityConfig) Option {
+	return func(s *Settings) {
+		s.Model.Diversity = d
+	}
+}
+
+// WithIslands задаёт топологию и политику миграции островной модели
+func WithIslands(i IslandConfig) Option {
+	return func(s *Settings) {
+		s.Model.Islands = i
+	}
+}
+
+// WithLocalSearch задаёт локальный поиск меметической модели
+func WithLocalSearch(l LocalSearchConfig) Option {
+	return func(s *Settings) {
+		s.Model.LocalSearch = l
+	}
+}
+
+// WithInitialization задаёт способы построения начальной популяции
+func WithInitialization(i InitializationConfig) Option {
+	return func(s *Settings) {
+		s.Model.Initialization = i
+	}
+}
+
+// WithProblem выбирает решаемую задачу
+func WithProblem(p ProblemType) Option {
+	return func(s *Settings) {
+		s.Problem = p
+	}
+}
+
+// WithMultiObjective задаёт целевые функции модели NSGA-II
+func WithMultiObjective(m MultiObjectiveConfig) Option {
+	return func(s *Settings) {
+		s.Model.MultiObjective = m
+	}
+}
+
+// WithSteadyState задаёт размер шага и политику замены модели с постоянным состоянием
+func WithSteadyState(c SteadyStateConfig) Option {
+	return func(s *Settings) {
+		s.Model.SteadyState = c
+	}
+}
+
+// WithCellular задаёт решётку и окрестности клеточной модели
+func WithCellular(c CellularConfig) Option {
+	return func(s *Settings) {
+		s.Model.Cellular = c
+	}
+}
+
+// WithEDA задаёт вариант и параметры модели оценки распределения
+func WithEDA(c EDAConfig) Option {
+	return func(s *Settings) {
+		s.Model.EDA = c
+	}
+}
+
+// WithBaselines задаёт параметры имитации отжига и поиска с запретами
+func WithBaselines(annealing AnnealingConfig, tabu TabuConfig) Option {
+	return func(s *Settings) {
+		s.Model.Annealing = annealing
+		s.Model.Tabu = tabu
+	}
+}
+
+// WithACO задаёт параметры муравьиного алгоритма
+func WithACO(c ACOConfig) Option {
+	return func(s *Settings) {
+		s.Model.ACO = c
+	}
+}
+
+// WithRateControl задаёт расписания и адаптацию вероятностей мутации и кроссовера
+func WithRateControl(c RateControlConfig) Option {
+	return func(s *Settings) {
+		s.RateControl = c
 	}
+}
 
-	config := EvolutionModelConfig{
-		Model: evolutionModel,
+// WithStagnation задаёт реакцию на стагнацию лучшего решения
+func WithStagnation(c StagnationConfig) Option {
+	return func(s *Settings) {
+		s.Stagnation = c
+	}
+}
 
-		UseSelection:   true,
-		UseCrossover:   true,
-		UseMutation:    true,
-		UseLocalSearch: evolutionModel == Memetic,
+// WithRepair задаёт стратегию починки потомков (перекрывает выбор по Config.UseFastRepair)
+func WithRepair(r RepairStrategy) Option {
+	return func(s *Settings) {
+		s.Config.Repair = r
 	}
+}
 
-	modelStrategy, err := NewEvolutionModelStrategy(config)
+// Validate проверяет согласованность настроек для генома длины genomeLength
+// (число рёбер для паросочетания, см. ProblemType.GenomeLength)
+func (s Settings) Validate(genomeLength int) error {
+	cfg := s.Config
+	if cfg.PopulationSize < 1 {
+		return fmt.Errorf("population size must be at least 1, got %d", cfg.PopulationSize)
+	}
+	if cfg.Generations < 1 {
+		return fmt.Errorf("generations must be at least 1, got %d", cfg.Generations)
+	}
+	if cfg.MutationRate < 0 || cfg.MutationRate > 1 {
+		return fmt.Errorf("mutation rate must be in [0, 1], got %v", cfg.MutationRate)
+	}
+	if s.CrossoverRate < 0 || s.CrossoverRate > 1 {
+		return fmt.Errorf("crossover rate must be in [0, 1], got %v", s.CrossoverRate)
+	}
+	if cfg.CacheSize < 0 {
+		return fmt.Errorf("cache size must not be negative, got %d", cfg.CacheSize)
+	}
+	if s.EliteSize < 0 {
+		return fmt.Errorf("elite size must not be negative, got %d", s.EliteSize)
+	}
+	if s.EliteSize >= cfg.PopulationSize {
+		return fmt.Errorf("elite size %d must be smaller than population size %d: no offspring would be produced",
+			s.EliteSize, cfg.PopulationSize)
+	}
+	if err := checkProblemOperators(s.Problem, s.Selection, s.Mutation); err != nil {
+		return err
+	}
+	for _, ops := range s.Model.Islands.Operators {
+		if err := checkProblemOperators(s.Problem, ops.Selection, ops.Mutation); err != nil {
+			return err
+		}
+	}
+	if s.Model.Model == NSGA2 && s.Problem != Matching {
+		return fmt.Errorf("NSGA-II objectives are defined for matching, not for %v", s.Problem)
+	}
+	if s.Model.Model == AntColony && s.Problem != Matching {
+		return fmt.Errorf("ant colony builds matchings, not solutions of %v", s.Problem)
+	}
+	if err := s.RateControl.Validate(); err != nil {
+		return err
+	}
+	if s.RateControl.Enabled() && !s.Model.Model.usesVariationRates() {
+		return fmt.Errorf("%v model does not use mutation and crossover rates: rate schedules and adaptation would have no effect",
+			s.Model.Model)
+	}
+	if err := s.Stagnation.Validate(cfg.PopulationSize); err != nil {
+		return err
+	}
+	if s.Stagnation.Enabled() && s.Stagnation.Response == RestartHypermutation {
+		if s.RateControl.Adaptation == AdaptSelf {
+			return errors.New("hypermutation cannot raise self-adaptive mutation rates: individuals carry their own rates")
+		}
+		if !s.Model.Model.usesVariationRates() {
+			return fmt.Errorf("hypermutation raises the mutation rate, which %v model does not use", s.Model.Model)
+		}
+	}
+	if s.Stagnation.Enabled() && s.Stagnation.Response == RestartPartial && s.Model.Model.trajectoryModel() {
+		return fmt.Errorf("partial restart keeps the elite of a population, but %v model keeps a single solution: use random immigrants or full restart",
+			s.Model.Model)
+	}
+	// Обязательны только операторы, которые модель действительно применяет
+	model, err := NewEvolutionModelStrategy(s.Model)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	provided := map[string]bool{
+		"Selection": s.Selection != nil,
+		"Crossover": s.Crossover != nil,
+		"Mutation":  s.Mutation != nil,
+	}
+	for _, name := range model.GetRequiredStrategies() {
+		if !provided[name] {
+			return fmt.Errorf("%s strategy is required for %v model", strings.ToLower(name), s.Model.Model)
+		}
 	}
 
-	// Привязываем rate к кроссоверу
-	cs := crossoverStrategy.WithRate(crossoverRate)
+	if s.Model.Model == SteadyState {
+		if err := s.Model.SteadyState.Validate(cfg.PopulationSize); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Model.Diversity.check(s.Model.Model); err != nil {
+		return err
+	}
+
+	if s.Model.Model == Cellular {
+		if err := s.Model.Cellular.Validate(cfg.PopulationSize); err != nil {
+			return err
+		}
+	}
+
+	if s.Model.Model == EDA {
+		if err := s.Model.EDA.Validate(cfg.PopulationSize); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Model.Annealing.Validate(); err != nil {
+		return err
+	}
+	if err := s.Model.Tabu.Validate(); err != nil {
+		return err
+	}
+	if err := s.Model.ACO.Validate(); err != nil {
+		return err
+	}
+
+	if s.Model.Model == Island {
+		if s.NumIslands < 1 {
+			return fmt.Errorf("island model needs at least 1 island, got %d", s.NumIslands)
+		}
+		if s.NumIslands > cfg.PopulationSize {
+			return fmt.Errorf("%d islands cannot be populated by %d individuals: number of islands must not exceed population size",
+				s.NumIslands, cfg.PopulationSize)
+		}
+		if s.MigrationInterval < 1 {
+			return fmt.Errorf("migration interval must be at least 1 for island model, got %d", s.MigrationInterval)
+		}
+		islandSize := cfg.PopulationSize / s.NumIslands
+		if elite := s.eliteSize(); elite >= islandSize {
+			return fmt.Errorf("elite size %d must be smaller than island size %d (%d individuals on %d islands)",
+				elite, islandSize, cfg.PopulationSize, s.NumIslands)
+		}
+		for i, ops := range s.Model.Islands.Operators {
+			if ops.EliteSize < 0 {
+				return fmt.Errorf("island %d: elite size must not be negative, got %d", i, ops.EliteSize)
+			}
+			if ops.EliteSize >= islandSize {
+				return fmt.Errorf("island %d: elite size %d must be smaller than island size %d (%d individuals on %d islands)",
+					i, ops.EliteSize, islandSize, cfg.PopulationSize, s.NumIslands)
+			}
+		}
+	}
+
+	return s.Model.Initialization.Validate(genomeLength)
+}
+
+// ValidateGraph проверяет настройки, зависящие от графа: стоимости и ёмкости вершин
+// и применимость заданного локального поиска
+func (s Settings) ValidateGraph(graph *Graph) error {
+	if err := validateCosts(graph); err != nil {
+		return err
+	}
+	if err := validateCapacities(graph); err != nil {
+		return err
+	}
+	if s.Model.usesLocalSearch() {
+		return s.Model.LocalSearch.check(s.Problem, graph)
+	}
+	return nil
+}
 
-	// Если это турнирная селекция, убедимся, что EliteSize и TournamentSize заданы
-	eliteSize := populationSize / 10
+// eliteSize возвращает число элитных особей с учётом значения по умолчанию
+func (s Settings) eliteSize() int {
+	if s.EliteSize > 0 {
+		return s.EliteSize
+	}
+	eliteSize := s.Config.PopulationSize / 10
 	if eliteSize < 1 {
 		eliteSize = 1
 	}
-	ew := ElitismWrapper{
-		Strategy:  selectionStrategy,
-		EliteSize: eliteSize,
+	return eliteSize
+}
+
+// New создаёт алгоритм по настройкам DefaultSettings, изменённым опциями.
+// Все комбинации параметров проверяются до создания алгоритма.
+func New(graph *Graph, opts ...Option) (*Algorithm, error) {
+	if graph == nil {
+		return nil, errors.New("graph is required")
+	}
+	s := NewSettings(opts...)
+	if err := s.Validate(s.Problem.GenomeLength(graph)); err != nil {
+		return nil, err
+	}
+	if err := s.ValidateGraph(graph); err != nil {
+		return nil, err
+	}
+	problem, err := NewProblem(s.Problem, graph, s.Config.RepairStrategy())
+	if err != nil {
+		return nil, err
+	}
+
+	// Починка выбирается в Config и передаётся моделям через ModelConfig
+	s.Model.Repair = s.Config.RepairStrategy()
+
+	modelStrategy, err := NewEvolutionModelStrategy(s.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	// Привязываем rate к кроссоверу
+	var cs CrossoverStrategy
+	if s.Crossover != nil {
+		cs = s.Crossover.WithRate(s.CrossoverRate)
 	}
 
 	ga := &Algorithm{
-		Graph:             graph,
-		EvolutionModel:    modelStrategy,
-		CrossoverStrategy: cs,
-		SelectionStrategy: ew,
-		MutationStrategy:  mutationStrategy,
-		PopulationSize:    populationSize,
-		Generations:       generations,
-		MutationRate:      mutationRate,
-		CrossoverRate:     crossoverRate,
-		NumIslands:        numIslands,
-		MigrationInterval: migrationInterval,
-		Logger:            NewLogger(),
-		optimalSize:       MaxMatchingGreed(graph),
+		Graph:                 graph,
+		EvolutionModel:        modelStrategy,
+		CrossoverStrategy:     cs,
+		SelectionStrategy:     ElitismWrapper{Strategy: s.Selection, EliteSize: s.eliteSize()},
+		MutationStrategy:      s.Mutation,
+		PopulationSize:        s.Config.PopulationSize,
+		Generations:           s.Config.Generations,
+		MutationRate:          s.Config.MutationRate,
+		CrossoverRate:         s.CrossoverRate,
+		NumIslands:            s.NumIslands,
+		MigrationInterval:     s.MigrationInterval,
+		ModelConfig:           s.Model,
+		Config:                s.Config,
+		Logger:                NewLogger(),
+		Problem:               problem,
+		optimalSize:           -1,
+		useOptimalTermination: s.OptimalTermination,
+	}
+	if optimum, ok := problem.ReferenceOptimum(); ok {
+		ga.optimalSize = optimum
+	}
+
+	if s.RateControl.Enabled() {
+		ga.rates = newRateController(s.RateControl, s.Config.MutationRate, s.CrossoverRate)
+	}
+	if s.Stagnation.Enabled() {
+		ga.stagnation = &stagnationMonitor{cfg: s.Stagnation}
+	}
+
+	if s.Config.UseCachedFitness {
+		ga.fitnessCache = NewFitnessCache(s.Config.CacheSize)
 	}
 
 	if err := modelStrategy.ValidateStrategies(ga); err != nil {
@@ -71,3 +483,26 @@ func NewGeneticAlgorithm(
 
 	return ga, nil
 }
+
+// NewGeneticAlgorithm создаёт экземпляр алгоритма с заданными параметрами.
+// Сохранён для совместимости; эквивалентен New с соответствующими опциями.
+func NewGeneticAlgorithm(
+	graph *Graph,
+	evolutionModel EvolutionModel,
+	crossoverStrategy CrossoverStrategy,
+	selectionStrategy SelectionStrategy,
+	mutationStrategy MutationStrategy,
+	populationSize, generations int,
+	mutationRate, crossoverRate float64,
+	numIslands, migrationInterval int,
+	opts ...Option,
+) (*Algorithm, error) {
+	base := []Option{
+		WithModel(evolutionModel),
+		WithOperators(selectionStrategy, crossoverStrategy, mutationStrategy),
+		WithPopulation(populationSize, generations),
+		WithRates(mutationRate, crossoverRate),
+		WithIslandCount(numIslands, migrationInterval),
+	}
+	return New(graph, append(base, opts...)...)
+}
@@ -0,0 +1,337 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Траекторные метаэвристики для сравнения с генетическим алгоритмом: имитация отжига
+// и поиск с запретами. Обе модели ведут одно текущее решение в той же кодировке и за поколение
+// делают PopulationSize оценок приспособленности, как генерационная модель, поэтому
+// их результаты сопоставимы по числу поколений. Популяция алгоритма — текущее решение.
+// Случайные иммигранты и полный перезапуск при стагнации заменяют его случайным решением,
+// и траектория начинается заново: отжиг заново подбирает температуру, запреты снимаются.
+
+// trajectoryModel сообщает, ведёт ли модель одно решение вместо популяции
+func (m EvolutionModel) trajectoryModel() bool {
+	return m == SimulatedAnnealing || m == TabuSearch
+}
+
+// ------------------------ Окрестность ------------------------ //
+
+// AugmentMoveProbability — доля ходов, выполняемых увеличивающей цепью (для паросочетания)
+const AugmentMoveProbability = 0.1
+
+// trajectoryMoves строит соседние решения: переворот гена, для паросочетания — с освобождением
+// концов добавляемого ребра, а также увеличивающую цепь
+type trajectoryMoves struct {
+	incident [][]int // Рёбра, инцидентные вершине (только для паросочетания)
+	augment  AugmentingPathMutationStrategy
+}
+
+func newTrajectoryMoves(ga *Algorithm) *trajectoryMoves {
+	mv := &trajectoryMoves{}
+	if ga.Problem.Type() == Matching {
+		mv.incident = make([][]int, ga.Graph.NumVertices)
+		for i, e := range ga.Graph.Edges {
+			mv.incident[e.U] = append(mv.incident[e.U], i)
+			if e.V != e.U {
+				mv.incident[e.V] = append(mv.incident[e.V], i)
+			}
+		}
+	}
+	return mv
+}
+
+// neighbour возвращает починенного и оценённого соседа current и изменённые гены
+func (mv *trajectoryMoves) neighbour(ga *Algorithm, current Chromosome) (Chromosome, []int) {
+	next := copyChromosome(current)
+	if mv.incident != nil && rng.Float64() < AugmentMoveProbability {
+		mv.augment.Mutate(&next, 1, ga.Graph)
+	} else {
+		i := rng.Intn(len(next.Genes))
+		next.Genes[i] = !next.Genes[i]
+		if mv.incident != nil && next.Genes[i] {
+			// Добавляемое ребро вытесняет рёбра у насыщенных концов, иначе починка его отбросит
+			e := ga.Graph.Edges[i]
+			mv.release(ga.Graph, &next, e.U, i)
+			mv.release(ga.Graph, &next, e.V, i)
+		}
+	}
+	ga.repair(&next)
+	ga.evaluate(&next)
+
+	var changed []int
+	for i := range next.Genes {
+		if next.Genes[i] != current.Genes[i] {
+			changed = append(changed, i)
+		}
+	}
+	return next, changed
+}
+
+// release снимает случайные рёбра у вершины v, пока ребро keep не поместится в её ёмкость
+func (mv *trajectoryMoves) release(graph *Graph, chrom *Chromosome, v, keep int) {
+	var on []int
+	for _, i := range mv.incident[v] {
+		if i != keep && chrom.Genes[i] {
+			on = append(on, i)
+		}
+	}
+	rng.Shuffle(len(on), func(a, b int) { on[a], on[b] = on[b], on[a] })
+	for k := 0; k < len(on) && len(on)-k >= graph.Capacity(v); k++ {
+		chrom.Genes[on[k]] = false
+	}
+}
+
+// startTrajectory выбирает начальное решение: лучшую особь популяции (в том числе после перезапуска)
+// и оставляет его единственной особью
+func startTrajectory(ga *Algorithm) Chromosome {
+	start := copyChromosome(ga.GetBestChromosome())
+	ga.Population = []Chromosome{start}
+	return start
+}
+
+// finishTrajectoryStep завершает поколение траекторной модели
+func finishTrajectoryStep(ga *Algorithm, current, best Chromosome) {
+	ga.Population = []Chromosome{current}
+	ga.SetBestSoFar(best)
+	ga.SetLocalBest(current)
+	ga.adaptRates()
+	ga.checkStagnation()
+	ga.CurrentGeneration++
+	ga.Logger.LogGeneration(ga)
+
+	if ga.ShouldTerminate() {
+		ga.Logger.LogCompletion(ga)
+	}
+}
+
+// ------------------------ Имитация отжига ------------------------ //
+
+// AnnealingConfig задаёт имитацию отжига
+type AnnealingConfig struct {
+	InitialTemperature float64 // Начальная температура (0 — подбирается так, чтобы 80% ухудшений принимались)
+	FinalTemperature   float64 // Температура к последнему поколению (0 — 0.1% начальной)
+}
+
+// Validate проверяет настройки
+func (c AnnealingConfig) Validate() error {
+	if c.InitialTemperature < 0 || c.FinalTemperature < 0 {
+		return fmt.Errorf("annealing temperatures must not be negative, got %v and %v", c.InitialTemperature, c.FinalTemperature)
+	}
+	if c.InitialTemperature > 0 && c.FinalTemperature > c.InitialTemperature {
+		return fmt.Errorf("final temperature %v exceeds initial temperature %v", c.FinalTemperature, c.InitialTemperature)
+	}
+	return nil
+}
+
+// AnnealingModel реализует имитацию отжига с геометрическим охлаждением
+type AnnealingModel struct {
+	Config AnnealingConfig
+
+	moves       *trajectoryMoves
+	current     Chromosome
+	best        Chromosome
+	temperature float64
+	cooling     float64 // Множитель температуры за ход
+}
+
+// calibrate подбирает начальную температуру по среднему ухудшению случайных ходов
+func (m *AnnealingModel) calibrate(ga *Algorithm) float64 {
+	if m.Config.InitialTemperature > 0 {
+		return m.Config.InitialTemperature
+	}
+	sum, n := 0.0, 0
+	for k := 0; k < 50; k++ {
+		next, _ := m.moves.neighbour(ga, m.current)
+		if d := m.current.Fitness - next.Fitness; d > 0 {
+			sum += float64(d)
+			n++
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return -(sum / float64(n)) / math.Log(0.8)
+}
+
+func (m *AnnealingModel) Evolve(ga *Algorithm) error {
+	if len(ga.Population) == 0 {
+		return errors.New("empty population")
+	}
+	if m.moves == nil {
+		m.moves = newTrajectoryMoves(ga)
+	}
+	if m.current.Genes == nil {
+		// Первое поколение или перезапуск: цепь начинается заново с остатком бюджета ходов
+		m.current = startTrajectory(ga)
+		if m.best.Genes == nil || m.current.Fitness > m.best.Fitness {
+			m.best = copyChromosome(m.current)
+		}
+		m.temperature = m.calibrate(ga)
+		final := m.Config.FinalTemperature
+		if final == 0 {
+			final = m.temperature / 1000
+		}
+		remaining := max((ga.Generations-ga.CurrentGeneration)*ga.PopulationSize, 1)
+		m.cooling = math.Pow(final/m.temperature, 1/float64(remaining))
+	}
+
+	accepted := 0
+	for k := 0; k < ga.PopulationSize; k++ {
+		next, _ := m.moves.neighbour(ga, m.current)
+		delta := float64(next.Fitness - m.current.Fitness)
+		if delta >= 0 || rng.Float64() < math.Exp(delta/m.temperature) {
+			m.current = next
+			accepted++
+			if m.current.Fitness > m.best.Fitness {
+				m.best = copyChromosome(m.current)
+			}
+		}
+		m.temperature *= m.cooling
+	}
+
+	finishTrajectoryStep(ga, m.current, m.best)
+	ga.Logger.LogInfo("Отжиг: температура=%.4f, принято ходов=%d из %d", m.temperature, accepted, ga.PopulationSize)
+	return nil
+}
+
+// resetSlots начинает цепь заново со случайного решения, которым перезапуск заменил текущее
+func (m *AnnealingModel) resetSlots(_ *Algorithm, _ []int) {
+	m.current = Chromosome{}
+}
+
+func (m *AnnealingModel) GetRequiredStrategies() []string {
+	return nil
+}
+
+func (m *AnnealingModel) ValidateStrategies(ga *Algorithm) error {
+	return m.Config.Validate()
+}
+
+func (m *AnnealingModel) GetModelName() string {
+	return "SimulatedAnnealing"
+}
+
+func (m *AnnealingModel) String() string {
+	return "SimulatedAnnealing"
+}
+
+// ------------------------ Поиск с запретами ------------------------ //
+
+// TabuConfig задаёт поиск с запретами
+type TabuConfig struct {
+	Tenure     int // Сколько итераций изменённые гены нельзя менять снова (0 — √длины генома)
+	Candidates int // Соседей, просматриваемых за итерацию (0 — 20)
+}
+
+// Validate проверяет настройки
+func (c TabuConfig) Validate() error {
+	if c.Tenure < 0 || c.Candidates < 0 {
+		return fmt.Errorf("tabu tenure and candidate count must not be negative, got %d and %d", c.Tenure, c.Candidates)
+	}
+	return nil
+}
+
+// TabuModel реализует поиск с запретами: за итерацию выбирается лучший из Candidates соседей,
+// не меняющий запрещённых генов; запрет снимается, если сосед лучше найденного ранее (аспирация)
+type TabuModel struct {
+	Config TabuConfig
+
+	moves     *trajectoryMoves
+	current   Chromosome
+	best      Chromosome
+	tabuUntil []int // Итерация, до которой ген запрещён
+	iteration int
+}
+
+func (m *TabuModel) Evolve(ga *Algorithm) error {
+	if len(ga.Population) == 0 {
+		return errors.New("empty population")
+	}
+	if m.moves == nil {
+		m.moves = newTrajectoryMoves(ga)
+	}
+	if m.current.Genes == nil {
+		// Первое поколение или перезапуск: поиск начинается заново без запретов
+		m.current = startTrajectory(ga)
+		if m.best.Genes == nil || m.current.Fitness > m.best.Fitness {
+			m.best = copyChromosome(m.current)
+		}
+		m.tabuUntil = make([]int, len(m.current.Genes))
+	}
+	tenure := m.Config.Tenure
+	if tenure == 0 {
+		tenure = max(int(math.Sqrt(float64(len(m.current.Genes)))), 1)
+	}
+	candidates := m.Config.Candidates
+	if candidates == 0 {
+		candidates = 20
+	}
+
+	// За поколение делается PopulationSize оценок, как в генерационной модели
+	for evaluations := 0; evaluations < ga.PopulationSize; {
+		var chosen Chromosome
+		var chosenChanged []int
+		for k := 0; k < candidates && evaluations < ga.PopulationSize; k++ {
+			next, changed := m.moves.neighbour(ga, m.current)
+			evaluations++
+			if len(changed) == 0 {
+				continue
+			}
+			if m.isTabu(changed) && next.Fitness <= m.best.Fitness {
+				continue
+			}
+			if chosen.Genes == nil || next.Fitness > chosen.Fitness {
+				chosen, chosenChanged = next, changed
+			}
+		}
+		m.iteration++
+		if chosen.Genes == nil {
+			continue
+		}
+		m.current = chosen
+		for _, i := range chosenChanged {
+			m.tabuUntil[i] = m.iteration + tenure
+		}
+		if m.current.Fitness > m.best.Fitness {
+			m.best = copyChromosome(m.current)
+		}
+	}
+
+	finishTrajectoryStep(ga, m.current, m.best)
+	return nil
+}
+
+// isTabu сообщает, меняет ли ход запрещённый ген
+func (m *TabuModel) isTabu(changed []int) bool {
+	for _, i := range changed {
+		if m.tabuUntil[i] > m.iteration {
+			return true
+		}
+	}
+	return false
+}
+
+// resetSlots начинает поиск заново со случайного решения, которым перезапуск заменил текущее
+func (m *TabuModel) resetSlots(_ *Algorithm, _ []int) {
+	m.current = Chromosome{}
+}
+
+func (m *TabuModel) GetRequiredStrategies() []string {
+	return nil
+}
+
+func (m *TabuModel) ValidateStrategies(ga *Algorithm) error {
+	return m.Config.Validate()
+}
+
+func (m *TabuModel) GetModelName() string {
+	return "TabuSearch"
+}
+
+func (m *TabuModel) String() string {
+	return "TabuSearch"
+}
@@ -0,0 +1,252 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// b-паросочетание: вершина v может входить не более чем в Capacities[v] рёбер решения.
+// При Capacities == nil ёмкость каждой вершины равна 1 (обычное паросочетание).
+
+// Capacity возвращает ёмкость вершины v
+func (g *Graph) Capacity(v int) int {
+	if g.Capacities == nil {
+		return 1
+	}
+	return g.Capacities[v]
+}
+
+// validateCapacities проверяет ёмкости вершин
+func validateCapacities(graph *Graph) error {
+	if graph.Capacities == nil {
+		return nil
+	}
+	if len(graph.Capacities) != graph.NumVertices {
+		return fmt.Errorf("graph has %d capacities for %d vertices", len(graph.Capacities), graph.NumVertices)
+	}
+	for v, c := range graph.Capacities {
+		if c < 0 {
+			return fmt.Errorf("vertex %d has negative capacity %d", v, c)
+		}
+	}
+	if graph.Costs != nil {
+		return errors.New("vertex capacities are not supported for weighted assignment")
+	}
+	return nil
+}
+
+// vertexLoad отслеживает, сколько рёбер решения инцидентно каждой вершине
+type vertexLoad struct {
+	graph *Graph
+	load  []int
+}
+
+func newVertexLoad(graph *Graph) *vertexLoad {
+	return &vertexLoad{graph: graph, load: make([]int, graph.NumVertices)}
+}
+
+// fits сообщает, можно ли добавить ребро e, не превысив ёмкости концов
+func (l *vertexLoad) fits(e Edge) bool {
+	return l.load[e.U] < l.graph.Capacity(e.U) && l.load[e.V] < l.graph.Capacity(e.V)
+}
+
+func (l *vertexLoad) add(e Edge) {
+	l.load[e.U]++
+	l.load[e.V]++
+}
+
+func (l *vertexLoad) remove(e Edge) {
+	l.load[e.U]--
+	l.load[e.V]--
+}
+
+// full сообщает, исчерпана ли ёмкость вершины v
+func (l *vertexLoad) full(v int) bool {
+	return l.load[v] >= l.graph.Capacity(v)
+}
+
+// residual возвращает оставшуюся ёмкость вершины v
+func (l *vertexLoad) residual(v int) int {
+	return l.graph.Capacity(v) - l.load[v]
+}
+
+// ValidMatchingEdges возвращает индексы рёбер допустимой части решения:
+// рёбра берутся по возрастанию индекса, пока позволяют ёмкости концов
+func ValidMatchingEdges(genes []bool, graph *Graph) []int {
+	load := newVertexLoad(graph)
+	var indices []int
+	for i, on := range genes {
+		if on && load.fits(graph.Edges[i]) {
+			load.add(graph.Edges[i])
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// ------------------------ Точное b-паросочетание ------------------------ //
+
+// MaxBMatching находит b-паросочетание наибольшей мощности сведением к обычному паросочетанию:
+// вершина v заменяется Capacity(v) копиями, ребро (u, v) — путём u' — e_u — e_v — v',
+// где e_u смежна со всеми копиями u, а e_v — со всеми копиями v. Ребро входит в решение,
+// если e_u и e_v сочетаются с копиями, а не друг с другом.
+func MaxBMatching(graph *Graph) []bool {
+	copies := make([][]int, graph.NumVertices)
+	n := 0
+	for v := range copies {
+		for k := 0; k < graph.Capacity(v); k++ {
+			copies[v] = append(copies[v], n)
+			n++
+		}
+	}
+
+	gadget := &Graph{}
+	middle := make([]int, len(graph.Edges)) // Индекс ребра e_u — e_v в gadget
+	for i, e := range graph.Edges {
+		eu, ev := n, n+1
+		n += 2
+		middle[i] = len(gadget.Edges)
+		gadget.Edges = append(gadget.Edges, Edge{U: eu, V: ev})
+		for _, c := range copies[e.U] {
+			gadget.Edges = append(gadget.Edges, Edge{U: c, V: eu})
+		}
+		for _, c := range copies[e.V] {
+			gadget.Edges = append(gadget.Edges, Edge{U: ev, V: c})
+		}
+	}
+	gadget.NumVertices = n
+
+	// Начинаем с паросочетания из средних рёбер: оно уже покрывает все вспомогательные вершины
+	chrom := Chromosome{Genes: make([]bool, len(gadget.Edges))}
+	for _, m := range middle {
+		chrom.Genes[m] = true
+	}
+	(&BlossomSearch{}).Improve(&chrom, gadget)
+
+	genes := make([]bool, len(graph.Edges))
+	for i, m := range middle {
+		genes[i] = !chrom.Genes[m]
+	}
+	return genes
+}
+
+// --------------- Увеличивающие цепи с учётом ёмкостей --------------- //
+
+// CapacitatedAugmentingSearch ищет чередующиеся цепи с попарно различными рёбрами между
+// вершинами с остаточной ёмкостью; внутренние вершины цепи сохраняют загрузку, концевые
+// получают по ребру. Как и AugmentingPathSearch, нечётные циклы не обрабатываются.
+type CapacitatedAugmentingSearch struct {
+	MaxAugmentations int // Предел числа аугментаций (0 — до исчерпания)
+	MaxDepth         int // Предел длины цепи в рёбрах (0 — без ограничения)
+}
+
+func (s *CapacitatedAugmentingSearch) Improve(chrom *Chromosome, graph *Graph) {
+	RepairFast(chrom, graph)
+
+	adj := make([][]incidence, graph.NumVertices)
+	load := newVertexLoad(graph)
+	for i, e := range graph.Edges {
+		if e.U == e.V {
+			continue
+		}
+		adj[e.U] = append(adj[e.U], incidence{to: e.V, edge: i})
+		adj[e.V] = append(adj[e.V], incidence{to: e.U, edge: i})
+		if chrom.Genes[i] {
+			load.add(e)
+		}
+	}
+
+	augmentations := 0
+	for improved := true; improved; {
+		improved = false
+		for _, start := range rng.Perm(graph.NumVertices) {
+			if load.residual(start) <= 0 {
+				continue
+			}
+			path := s.findTrail(start, adj, chrom.Genes, load)
+			if path == nil {
+				continue
+			}
+			for _, idx := range path {
+				chrom.Genes[idx] = !chrom.Genes[idx]
+			}
+			load.add(Edge{U: start, V: s.trailEnd(start, path, graph)})
+			improved = true
+			augmentations++
+			if s.MaxAugmentations > 0 && augmentations >= s.MaxAugmentations {
+				Evaluate(chrom, graph)
+				return
+			}
+		}
+	}
+	Evaluate(chrom, graph)
+}
+
+// findTrail ищет в глубину чередующуюся цепь из start: нечётные рёбра не входят в решение,
+// чётные входят. Состояние (вершина, чётность) посещается не более одного раза.
+func (s *CapacitatedAugmentingSearch) findTrail(start int, adj [][]incidence, genes []bool, load *vertexLoad) []int {
+	visited := make([][2]bool, len(adj))
+	onPath := make(map[int]bool)
+	var path []int
+
+	var dfs func(v int, wantMatched bool) bool
+	dfs = func(v int, wantMatched bool) bool {
+		phase := 0
+		if wantMatched {
+			phase = 1
+		}
+		if visited[v][phase] {
+			return false
+		}
+		visited[v][phase] = true
+		if s.MaxDepth > 0 && len(path) >= s.MaxDepth {
+			return false
+		}
+		for _, inc := range adj[v] {
+			if genes[inc.edge] != wantMatched || onPath[inc.edge] {
+				continue
+			}
+			path = append(path, inc.edge)
+			onPath[inc.edge] = true
+			if !wantMatched {
+				// Цепь можно замкнуть в вершине с остаточной ёмкостью (в start — если её хватит на два ребра)
+				need := 1
+				if inc.to == start {
+					need = 2
+				}
+				if load.residual(inc.to) >= need {
+					return true
+				}
+			}
+			if dfs(inc.to, !wantMatched) {
+				return true
+			}
+			path = path[:len(path)-1]
+			delete(onPath, inc.edge)
+		}
+		return false
+	}
+
+	if dfs(start, false) {
+		return path
+	}
+	return nil
+}
+
+// trailEnd возвращает последнюю вершину цепи path, начатой в start
+func (s *CapacitatedAugmentingSearch) trailEnd(start int, path []int, graph *Graph) int {
+	v := start
+	for _, idx := range path {
+		e := graph.Edges[idx]
+		if e.U == v {
+			v = e.V
+		} else {
+			v = e.U
+		}
+	}
+	return v
+}
+
+func (s *CapacitatedAugmentingSearch) GetName() string {
+	return "CapacitatedAugmenting"
+}
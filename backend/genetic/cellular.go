@@ -0,0 +1,245 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Клеточная (диффузионная) модель: популяция лежит на тороидальной решётке Width×Height,
+// особь i занимает клетку (i % Width, i / Width). Родители выбираются только из окрестности
+// клетки, потомок соревнуется только с её обитателем, поэтому хорошие решения
+// распространяются медленно и на решётке образуются ниши.
+
+// ------------------------ Настройки ------------------------ //
+
+// Neighbourhood определяет форму окрестности клетки
+type Neighbourhood int
+
+const (
+	// VonNeumann — клетки на манхэттенском расстоянии не больше радиуса
+	VonNeumann Neighbourhood = iota
+	// Moore — клетки на чебышёвском расстоянии не больше радиуса
+	Moore
+)
+
+func (n Neighbourhood) String() string {
+	switch n {
+	case VonNeumann:
+		return "VonNeumann"
+	case Moore:
+		return "Moore"
+	default:
+		return "Unknown"
+	}
+}
+
+// CellUpdate определяет порядок обновления клеток
+type CellUpdate int
+
+const (
+	// UpdateSynchronous — все потомки строятся по старой решётке и заменяют её одновременно
+	UpdateSynchronous CellUpdate = iota
+	// UpdateLineSweep — клетки обновляются на месте построчно
+	UpdateLineSweep
+	// UpdateRandomSweep — клетки обновляются на месте в новом случайном порядке каждое поколение
+	UpdateRandomSweep
+)
+
+func (u CellUpdate) String() string {
+	switch u {
+	case UpdateSynchronous:
+		return "Synchronous"
+	case UpdateLineSweep:
+		return "LineSweep"
+	case UpdateRandomSweep:
+		return "RandomSweep"
+	default:
+		return "Unknown"
+	}
+}
+
+// CellularConfig задаёт решётку клеточной модели
+type CellularConfig struct {
+	Width         int // Ширина решётки (0 вместе с Height — решётка, близкая к квадратной)
+	Height        int
+	Neighbourhood Neighbourhood
+	Radius        int // Радиус окрестности (0 — 1)
+	Update        CellUpdate
+}
+
+// Dimensions возвращает размеры решётки для популяции размера populationSize
+func (c CellularConfig) Dimensions(populationSize int) (width, height int, err error) {
+	if c.Width < 0 || c.Height < 0 {
+		return 0, 0, fmt.Errorf("cellular grid dimensions must not be negative, got %dx%d", c.Width, c.Height)
+	}
+	if c.Width == 0 && c.Height == 0 {
+		// Наибольший делитель, не превосходящий корня, даёт самую квадратную решётку
+		height = int(math.Sqrt(float64(populationSize)))
+		for height > 1 && populationSize%height != 0 {
+			height--
+		}
+		height = max(height, 1)
+		return populationSize / height, height, nil
+	}
+	if c.Width*c.Height != populationSize {
+		return 0, 0, fmt.Errorf("cellular grid %dx%d does not hold population of %d", c.Width, c.Height, populationSize)
+	}
+	return c.Width, c.Height, nil
+}
+
+// Validate проверяет настройки для популяции размера populationSize
+func (c CellularConfig) Validate(populationSize int) error {
+	if c.Radius < 0 {
+		return fmt.Errorf("neighbourhood radius must not be negative, got %d", c.Radius)
+	}
+	_, _, err := c.Dimensions(populationSize)
+	return err
+}
+
+// ------------------------ Модель ------------------------ //
+
+// CellularEvolutionModel реализует клеточный генетический алгоритм
+// Каждая клетка выбирает родителей в своей окрестности и заменяет обитателя потомком, если он не хуже
+type CellularEvolutionModel struct {
+	Config CellularConfig
+
+	neighbours [][]int // neighbours[i] — клетка i и её окрестность без повторов
+}
+
+// prepare строит окрестности клеток при первом поколении
+func (m *CellularEvolutionModel) prepare(size int) error {
+	if len(m.neighbours) == size {
+		return nil
+	}
+	width, height, err := m.Config.Dimensions(size)
+	if err != nil {
+		return err
+	}
+	radius := max(m.Config.Radius, 1)
+	m.neighbours = make([][]int, size)
+	for i := range m.neighbours {
+		x, y := i%width, i/width
+		seen := map[int]bool{i: true}
+		cells := []int{i}
+		for dy := -radius; dy <= radius; dy++ {
+			for dx := -radius; dx <= radius; dx++ {
+				if m.Config.Neighbourhood == VonNeumann && abs(dx)+abs(dy) > radius {
+					continue
+				}
+				// На маленькой решётке окрестность может обернуться вокруг тора и повторить клетку
+				j := ((y+dy+height)%height)*width + (x+dx+width)%width
+				if !seen[j] {
+					seen[j] = true
+					cells = append(cells, j)
+				}
+			}
+		}
+		m.neighbours[i] = cells
+	}
+	return nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (m *CellularEvolutionModel) Evolve(ga *Algorithm) error {
+	if len(ga.Population) == 0 {
+		return errors.New("empty population")
+	}
+	if err := m.prepare(len(ga.Population)); err != nil {
+		return err
+	}
+
+	order := make([]int, len(ga.Population))
+	for i := range order {
+		order[i] = i
+	}
+	if m.Config.Update == UpdateRandomSweep {
+		rng.Shuffle(len(order), func(a, b int) { order[a], order[b] = order[b], order[a] })
+	}
+
+	// При синхронном обновлении окрестности читаются из неизменной старой решётки
+	next := ga.Population
+	if m.Config.Update == UpdateSynchronous {
+		next = make([]Chromosome, len(ga.Population))
+		copy(next, ga.Population)
+	}
+
+	neighbourhood := make([]Chromosome, 0, len(m.neighbours[0]))
+	for _, i := range order {
+		neighbourhood = neighbourhood[:0]
+		for _, j := range m.neighbours[i] {
+			neighbourhood = append(neighbourhood, ga.Population[j])
+		}
+		parents := ga.selectParents(neighbourhood, 2)
+		child := ga.CrossoverStrategy.Crossover(parents[0], parents[1])
+		ga.mutate(&child, parents[0], parents[1])
+		ga.repair(&child)
+		ga.evaluate(&child)
+		ga.reportOffspring(child)
+		if child.Fitness >= ga.Population[i].Fitness {
+			next[i] = child
+		}
+	}
+
+	ga.Population = next
+	ga.SetLocalBest(ga.GetBestChromosome())
+	ga.adaptRates()
+	ga.checkStagnation()
+	ga.CurrentGeneration++
+	ga.Logger.LogGeneration(ga)
+
+	if ga.ShouldTerminate() {
+		ga.Logger.LogCompletion(ga)
+	}
+
+	return nil
+}
+
+func (m *CellularEvolutionModel) GetRequiredStrategies() []string {
+	return []string{"Selection", "Crossover", "Mutation"}
+}
+
+func (m *CellularEvolutionModel) ValidateStrategies(ga *Algorithm) error {
+	if ga.SelectionStrategy.Strategy == nil {
+		return errors.New("selection strategy is required for cellular model")
+	}
+	if ga.CrossoverStrategy == nil {
+		return errors.New("crossover strategy is required for cellular model")
+	}
+	if ga.MutationStrategy == nil {
+		return errors.New("mutation strategy is required for cellular model")
+	}
+	return m.Config.Validate(ga.PopulationSize)
+}
+
+func (m *CellularEvolutionModel) GetModelName() string {
+	return "Cellular"
+}
+
+func (m *CellularEvolutionModel) String() string {
+	return "Cellular"
+}
+
+// CellularGrid возвращает размеры решётки и приспособленность клеток по строкам;
+// ok == false, если модель не клеточная
+func (ga *Algorithm) CellularGrid() (width, height int, fitness []int, ok bool) {
+	m, isCellular := ga.EvolutionModel.(*CellularEvolutionModel)
+	if !isCellular {
+		return 0, 0, nil, false
+	}
+	width, height, err := m.Config.Dimensions(len(ga.Population))
+	if err != nil {
+		return 0, 0, nil, false
+	}
+	fitness = make([]int, len(ga.Population))
+	for i, c := range ga.Population {
+		fitness[i] = c.Fitness
+	}
+	return width, height, fitness, true
+}
@@ -1,9 +1,5 @@
 package genetic
 
-import (
-	"math/rand"
-)
-
 // ---------------------- Классический одноточечный кроссовер ---------------------- //
 
 type SinglePoint struct {
@@ -11,12 +7,13 @@ type SinglePoint struct {
 }
 
 func (s *SinglePoint) Crossover(p1, p2 Chromosome) Chromosome {
-	if rand.Float64() >= s.rate {
-		return p1
+	if rng.Float64() >= s.rate {
+		// Копия, чтобы мутация потомка не изменяла гены родителя в популяции
+		return copyChromosome(p1)
 	}
 
 	length := len(p1.Genes)
-	point := rand.Intn(length)
+	point := rng.Intn(length)
 	childGenes := make([]bool, length)
 	copy(childGenes[:point], p1.Genes[:point])
 	copy(childGenes[point:], p2.Genes[point:])
@@ -24,8 +21,9 @@ func (s *SinglePoint) Crossover(p1, p2 Chromosome) Chromosome {
 }
 
 func (s *SinglePoint) WithRate(rate float64) CrossoverStrategy {
-	s.rate = rate
-	return s
+	c := *s
+	c.rate = rate
+	return &c
 }
 
 func (c *SinglePoint) GetName() string {
@@ -39,13 +37,14 @@ type TwoPoint struct {
 }
 
 func (s *TwoPoint) Crossover(p1, p2 Chromosome) Chromosome {
-	if rand.Float64() >= s.rate {
-		return p1
+	if rng.Float64() >= s.rate {
+		// Копия, чтобы мутация потомка не изменяла гены родителя в популяции
+		return copyChromosome(p1)
 	}
 
 	length := len(p1.Genes)
-	point1 := rand.Intn(length)
-	point2 := rand.Intn(length)
+	point1 := rng.Intn(length)
+	point2 := rng.Intn(length)
 	if point1 > point2 {
 		point1, point2 = point2, point1
 	}
@@ -58,8 +57,9 @@ func (s *TwoPoint) Crossover(p1, p2 Chromosome) Chromosome {
 }
 
 func (s *TwoPoint) WithRate(rate float64) CrossoverStrategy {
-	s.rate = rate
-	return s
+	c := *s
+	c.rate = rate
+	return &c
 }
 
 func (c *TwoPoint) GetName() string {
@@ -68,23 +68,91 @@ func (c *TwoPoint) GetName() string {
 
 // ---------------------- Комбинированная стратегия кроссовера ---------------------- //
 
+// WeightedCrossover задаёт под-стратегию комбинированного кроссовера и её вес
+type WeightedCrossover struct {
+	Strategy CrossoverStrategy
+	Weight   float64
+}
+
+// CombinedCrossover выбирает один из под-операторов пропорционально весам.
+// При включённой адаптации веса смещаются в пользу операторов,
+// чьи потомки улучшают фитнес родителей.
 type CombinedCrossover struct {
 	strategies []CrossoverStrategy
+	selector   *operatorSelector
 	rate       float64
 }
 
+// crossoverOrigin связывает потомка с породившим его под-оператором комбинированного кроссовера,
+// чтобы награда доставалась верному оператору, даже если между Crossover и Feedback
+// тот же экземпляр успел породить других потомков (например, на другом острове)
+type crossoverOrigin struct {
+	owner   *operatorSelector // Селектор, общий для копий одного CombinedCrossover
+	op      int               // Индекс под-оператора
+	parents int               // Лучший фитнес родителей
+}
+
+// NewCombinedCrossover создаёт комбинированный кроссовер из взвешенных под-стратегий.
+// Под-стратегии применяются всегда (rate = 1), вероятность кроссовера задаёт сам CombinedCrossover.
+func NewCombinedCrossover(adaptation OperatorAdaptation, entries ...WeightedCrossover) *CombinedCrossover {
+	strategies := make([]CrossoverStrategy, 0, len(entries))
+	names := make([]string, 0, len(entries))
+	weights := make([]float64, 0, len(entries))
+	for _, e := range entries {
+		if e.Strategy == nil {
+			continue
+		}
+		strategies = append(strategies, e.Strategy.WithRate(1))
+		names = append(names, e.Strategy.GetName())
+		weights = append(weights, e.Weight)
+	}
+	return &CombinedCrossover{
+		strategies: strategies,
+		selector:   newOperatorSelector(names, weights, adaptation),
+		rate:       1,
+	}
+}
+
 func (s *CombinedCrossover) Crossover(p1, p2 Chromosome) Chromosome {
-	if rand.Float64() >= s.rate {
-		return p1
+	if len(s.strategies) == 0 || rng.Float64() >= s.rate {
+		// Копия, чтобы мутация потомка не изменяла гены родителя в популяции;
+		// копия не наследует происхождение родителя и не получает награды
+		return copyChromosome(p1)
+	}
+
+	op := s.selector.pick()
+	child := s.strategies[op].Crossover(p1, p2)
+	child.origin = &crossoverOrigin{owner: s.selector, op: op, parents: max(p1.Fitness, p2.Fitness)}
+	return child
+}
+
+// Feedback начисляет оператору, породившему потомка, награду — прирост фитнеса над лучшим родителем
+func (s *CombinedCrossover) Feedback(child Chromosome) {
+	o := child.origin
+	if o == nil || o.owner != s.selector {
+		return
+	}
+	reward := float64(child.Fitness - o.parents)
+	if reward < 0 {
+		reward = 0
 	}
+	s.selector.reward(o.op, reward)
+}
 
-	strategy := s.strategies[rand.Intn(len(s.strategies))]
-	return strategy.Crossover(p1, p2)
+// Stats возвращает статистику кредитования по каждому под-оператору
+func (s *CombinedCrossover) Stats() []OperatorStats {
+	if s.selector == nil {
+		return nil
+	}
+	return s.selector.snapshot()
 }
 
+// WithRate возвращает копию с общей статистикой под-операторов: награды копий
+// накапливаются в одном селекторе
 func (s *CombinedCrossover) WithRate(rate float64) CrossoverStrategy {
-	s.rate = rate
-	return s
+	c := *s
+	c.rate = rate
+	return &c
 }
 
 func (c *CombinedCrossover) GetName() string {
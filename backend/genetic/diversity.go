@@ -0,0 +1,282 @@
+package genetic
+
+import (
+	"fmt"
+	"math"
+)
+
+// ReplacementPolicy определяет, как потомок попадает в новую популяцию
+type ReplacementPolicy int
+
+const (
+	// ReplaceGenerational — потомок добавляется без соревнования (поведение по умолчанию)
+	ReplaceGenerational ReplacementPolicy = iota
+	// ReplaceDeterministicCrowding — потомок соревнуется с ближайшим по Хэммингу родителем
+	ReplaceDeterministicCrowding
+	// ReplaceRestrictedTournament — потомок соревнуется с ближайшей особью из случайного окна
+	ReplaceRestrictedTournament
+)
+
+func (r ReplacementPolicy) String() string {
+	switch r {
+	case ReplaceGenerational:
+		return "Generational"
+	case ReplaceDeterministicCrowding:
+		return "DeterministicCrowding"
+	case ReplaceRestrictedTournament:
+		return "RestrictedTournament"
+	default:
+		return "Unknown"
+	}
+}
+
+// DiversityConfig содержит настройки механизмов сохранения разнообразия
+type DiversityConfig struct {
+	SharingRadius       float64           // Радиус ниши σ для разделения фитнеса (доля длины генома, 0 — выключено)
+	SharingAlpha        float64           // Форма функции разделения (по умолчанию 1)
+	Replacement         ReplacementPolicy // Политика вставки потомков
+	RTRWindow           int               // Размер окна для restricted tournament replacement
+	EliminateDuplicates bool              // Заменять дубликаты генома случайными особями
+}
+
+// check проверяет, что модель учитывает включённые механизмы. Разделение фитнеса действует
+// при отборе родителей, политика вставки и устранение дубликатов — при замене особей;
+// модели, минующие эти шаги, молча проигнорировали бы настройки.
+func (c DiversityConfig) check(model EvolutionModel) error {
+	switch model {
+	case Classic, Island, SteadyState, Memetic, Combined:
+		return nil
+	case Cellular, EDA:
+		// Родители отбираются общим отбором, а потомки занимают клетку или строятся по модели
+		if c.Replacement != ReplaceGenerational || c.EliminateDuplicates {
+			return fmt.Errorf("%v model places offspring itself: only generational replacement without duplicate elimination is supported, got %v (eliminate duplicates: %v)",
+				model, c.Replacement, c.EliminateDuplicates)
+		}
+		return nil
+	default:
+		if c.SharingRadius > 0 || c.Replacement != ReplaceGenerational || c.EliminateDuplicates {
+			return fmt.Errorf("%v model does not support diversity mechanisms: disable fitness sharing, replacement policy and duplicate elimination",
+				model)
+		}
+		return nil
+	}
+}
+
+// DiversityStats содержит показатели разнообразия популяции
+type DiversityStats struct {
+	UniqueGenomes int     // Число уникальных геномов
+	MeanHamming   float64 // Среднее попарное расстояние Хэмминга
+	GeneEntropy   float64 // Средняя энтропия гена (бит)
+}
+
+// maxDuplicateRetries ограничивает число попыток заменить дубликат новой особью
+const maxDuplicateRetries = 3
+
+// hammingDistance возвращает число различающихся генов двух хромосом
+func hammingDistance(a, b Chromosome) int {
+	d := 0
+	for i := range a.Genes {
+		if a.Genes[i] != b.Genes[i] {
+			d++
+		}
+	}
+	return d
+}
+
+// ------------------------ Разделение фитнеса ------------------------ //
+
+// sharedPopulation возвращает копию популяции с приспособленностью, делённой на размер ниши,
+// и словарь истинных значений фитнеса по ключу генома
+func (ga *Algorithm) sharedPopulation(population []Chromosome) ([]Chromosome, map[string]int) {
+	cfg := ga.ModelConfig.Diversity
+	alpha := cfg.SharingAlpha
+	if alpha <= 0 {
+		alpha = 1
+	}
+	sigma := cfg.SharingRadius * float64(ga.Problem.GenomeLength())
+	if sigma < 1 {
+		sigma = 1
+	}
+
+	shared := make([]Chromosome, len(population))
+	original := make(map[string]int, len(population))
+	for i, c := range population {
+		niche := 0.0
+		for _, other := range population {
+			d := float64(hammingDistance(c, other))
+			if d < sigma {
+				niche += 1 - math.Pow(d/sigma, alpha)
+			}
+		}
+		shared[i] = c
+		// Масштабируем, чтобы сохранить точность в целочисленном фитнесе
+		shared[i].Fitness = int(math.Round(float64(c.Fitness) * 100 / niche))
+		original[chromosomeKey(c)] = c.Fitness
+	}
+	return shared, original
+}
+
+// ---------------------- Вставка потомков ---------------------- //
+
+// replacementPool возвращает рабочую копию популяции, в которую потомки вставляются
+// заменой (deterministic crowding, RTR). Для генерационной политики возвращает nil.
+func (ga *Algorithm) replacementPool(population []Chromosome) []Chromosome {
+	if ga.ModelConfig.Diversity.Replacement == ReplaceGenerational {
+		return nil
+	}
+	pool := make([]Chromosome, len(population))
+	copy(pool, population)
+	return pool
+}
+
+// acceptOffspring добавляет потомка в newPop с учётом политики замены и устранения дубликатов.
+// Если pool != nil, потомок соревнуется за место в pool, а newPop служит лишь счётчиком потомков.
+func (ga *Algorithm) acceptOffspring(newPop, pool []Chromosome, child, p1, p2 Chromosome) []Chromosome {
+	if pool != nil {
+		ga.replaceInPopulation(pool, child, p1, p2)
+		return append(newPop, child)
+	}
+	if ga.ModelConfig.Diversity.EliminateDuplicates {
+		child = ga.uniqueOffspring(newPop, child)
+	}
+	return append(newPop, child)
+}
+
+// survivors возвращает популяцию следующего поколения
+func survivors(newPop, pool []Chromosome) []Chromosome {
+	if pool != nil {
+		return pool
+	}
+	return newPop
+}
+
+// replaceInPopulation вставляет потомка на место особи в population.
+// Возвращает индекс заменённой особи или -1, если потомок отвергнут.
+// handled == false означает, что политика по умолчанию должна быть применена вызывающим кодом.
+func (ga *Algorithm) replaceInPopulation(population []Chromosome, child, p1, p2 Chromosome) (replaced int, handled bool) {
+	cfg := ga.ModelConfig.Diversity
+
+	if cfg.EliminateDuplicates && containsGenome(population, child) {
+		return -1, true
+	}
+
+	var target int
+	switch cfg.Replacement {
+	case ReplaceDeterministicCrowding:
+		nearest := p1
+		if hammingDistance(child, p2) < hammingDistance(child, p1) {
+			nearest = p2
+		}
+		target = closestIndex(population, nearest)
+	case ReplaceRestrictedTournament:
+		target = ga.closestInWindow(population, child)
+	default:
+		return -1, false
+	}
+
+	if child.Fitness > population[target].Fitness {
+		population[target] = child
+		return target, true
+	}
+	return -1, true
+}
+
+// closestInWindow возвращает индекс ближайшей к child особи среди RTRWindow случайных
+func (ga *Algorithm) closestInWindow(population []Chromosome, child Chromosome) int {
+	window := ga.ModelConfig.Diversity.RTRWindow
+	if window < 1 {
+		window = len(population) / 5
+		if window < 2 {
+			window = 2
+		}
+	}
+	best, bestDist := -1, math.MaxInt
+	for k := 0; k < window; k++ {
+		idx := rng.Intn(len(population))
+		if d := hammingDistance(child, population[idx]); d < bestDist {
+			best, bestDist = idx, d
+		}
+	}
+	return best
+}
+
+// closestIndex возвращает индекс ближайшей к target особи популяции
+func closestIndex(population []Chromosome, target Chromosome) int {
+	best, bestDist := 0, math.MaxInt
+	for i, c := range population {
+		if d := hammingDistance(c, target); d < bestDist {
+			best, bestDist = i, d
+			if d == 0 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// uniqueOffspring заменяет потомка новой случайной особью, если его геном уже есть в newPop
+func (ga *Algorithm) uniqueOffspring(newPop []Chromosome, child Chromosome) Chromosome {
+	for attempt := 0; attempt < maxDuplicateRetries && containsGenome(newPop, child); attempt++ {
+		child = ga.GenerateChromosome()
+	}
+	return child
+}
+
+func containsGenome(population []Chromosome, chrom Chromosome) bool {
+	for _, c := range population {
+		if hammingDistance(c, chrom) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// copyChromosome возвращает глубокую копию хромосомы
+func copyChromosome(chrom Chromosome) Chromosome {
+	genes := make([]bool, len(chrom.Genes))
+	copy(genes, chrom.Genes)
+	return Chromosome{Genes: genes, Fitness: chrom.Fitness, MutationRate: chrom.MutationRate}
+}
+
+// ---------------------- Статистика разнообразия ---------------------- //
+
+// ComputeDiversity вычисляет показатели разнообразия популяции
+func ComputeDiversity(population []Chromosome) DiversityStats {
+	var stats DiversityStats
+	n := len(population)
+	if n == 0 {
+		return stats
+	}
+
+	unique := make(map[string]struct{}, n)
+	for _, c := range population {
+		unique[chromosomeKey(c)] = struct{}{}
+	}
+	stats.UniqueGenomes = len(unique)
+
+	length := len(population[0].Genes)
+	if length == 0 {
+		return stats
+	}
+	// Попарное расстояние и энтропия считаются через частоты генов за O(N·L)
+	pairDiffs := 0.0
+	entropy := 0.0
+	for g := 0; g < length; g++ {
+		ones := 0
+		for _, c := range population {
+			if c.Genes[g] {
+				ones++
+			}
+		}
+		pairDiffs += float64(ones * (n - ones))
+		p := float64(ones) / float64(n)
+		if p > 0 && p < 1 {
+			entropy -= p*math.Log2(p) + (1-p)*math.Log2(1-p)
+		}
+	}
+	if n > 1 {
+		stats.MeanHamming = pairDiffs / (float64(n*(n-1)) / 2)
+	}
+	stats.GeneEntropy = entropy / float64(length)
+	return stats
+}
@@ -0,0 +1,293 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Модели оценки распределения (EDA): вместо кроссовера и мутации поддерживается вектор
+// вероятностей включения каждого гена (ребра для паросочетания, вершины для вершинных задач),
+// из которого сэмплируются и чинятся потомки; затем вектор сдвигается к лучшим из них.
+
+// ------------------------ Настройки ------------------------ //
+
+// EDAVariant определяет алгоритм обновления вектора вероятностей
+type EDAVariant int
+
+const (
+	// PBIL — вектор сдвигается к лучшим особям с темпом LearningRate
+	PBIL EDAVariant = iota
+	// UMDA — вектор заменяется частотами генов у отобранных особей
+	UMDA
+	// CompactGA — компактный ГА: победитель пары сдвигает вектор на 1/PopulationSize
+	CompactGA
+)
+
+func (v EDAVariant) String() string {
+	switch v {
+	case PBIL:
+		return "PBIL"
+	case UMDA:
+		return "UMDA"
+	case CompactGA:
+		return "cGA"
+	default:
+		return "Unknown"
+	}
+}
+
+// EDAConfig задаёт модель оценки распределения
+type EDAConfig struct {
+	Variant        EDAVariant
+	LearningRate   float64 // Темп обучения PBIL (0 — 0.1)
+	Learners       int     // Число лучших особей, к которым сдвигается PBIL (0 — 1)
+	SelectionRatio float64 // Доля популяции, по которой UMDA оценивает частоты (0 — 0.5)
+}
+
+// Validate проверяет настройки для популяции размера populationSize
+func (c EDAConfig) Validate(populationSize int) error {
+	if c.LearningRate < 0 || c.LearningRate > 1 {
+		return fmt.Errorf("PBIL learning rate must be in [0, 1], got %v", c.LearningRate)
+	}
+	if c.Learners < 0 || c.Learners > populationSize {
+		return fmt.Errorf("PBIL learners must be in [0, %d], got %d", populationSize, c.Learners)
+	}
+	if c.SelectionRatio < 0 || c.SelectionRatio > 1 {
+		return fmt.Errorf("UMDA selection ratio must be in [0, 1], got %v", c.SelectionRatio)
+	}
+	if c.Variant == CompactGA && populationSize < 2 {
+		return errors.New("compact GA needs a virtual population of at least 2")
+	}
+	return nil
+}
+
+// ------------------------ Модель ------------------------ //
+
+// EDAEvolutionModel реализует PBIL, UMDA и компактный ГА.
+// Популяция алгоритма — особи, сэмплированные в последнем поколении; элита сохраняется
+type EDAEvolutionModel struct {
+	Config EDAConfig
+
+	probs []float64 // Вероятность включения каждого гена
+}
+
+// clampProbability удерживает вероятность гена в [1/n, 1-1/n], чтобы вектор не вырождался
+func clampProbability(p float64, n int) float64 {
+	margin := 1 / float64(max(n, 2))
+	return math.Min(math.Max(p, margin), 1-margin)
+}
+
+// initProbabilities оценивает начальный вектор по частотам генов начальной популяции
+func (m *EDAEvolutionModel) initProbabilities(population []Chromosome) {
+	n := len(population[0].Genes)
+	m.probs = make([]float64, n)
+	for _, c := range population {
+		for i, on := range c.Genes {
+			if on {
+				m.probs[i]++
+			}
+		}
+	}
+	for i := range m.probs {
+		m.probs[i] = clampProbability(m.probs[i]/float64(len(population)), n)
+	}
+}
+
+// resetSlots сдвигает вектор к частотам генов особей, созданных перезапуском, пропорционально
+// их доле в популяции: полный перезапуск оценивает распределение заново, частичный сохраняет
+// вклад оставленной элиты. Иначе сэмплирование сразу вернуло бы прежние решения
+func (m *EDAEvolutionModel) resetSlots(ga *Algorithm, slots []int) {
+	if len(m.probs) == 0 || len(slots) == 0 {
+		return
+	}
+	share := float64(len(slots)) / float64(len(ga.Population))
+	freq := make([]float64, len(m.probs))
+	for _, idx := range slots {
+		for i, on := range ga.Population[idx].Genes {
+			if on {
+				freq[i]++
+			}
+		}
+	}
+	for i := range m.probs {
+		m.probs[i] = clampProbability((1-share)*m.probs[i]+share*freq[i]/float64(len(slots)), len(m.probs))
+	}
+}
+
+// sample строит, чинит и оценивает особь по вектору вероятностей
+func (m *EDAEvolutionModel) sample(ga *Algorithm) Chromosome {
+	child := Chromosome{Genes: make([]bool, len(m.probs))}
+	for i, p := range m.probs {
+		child.Genes[i] = rng.Float64() < p
+	}
+	ga.repair(&child)
+	ga.evaluate(&child)
+	return child
+}
+
+func (m *EDAEvolutionModel) Evolve(ga *Algorithm) error {
+	if len(ga.Population) == 0 {
+		return errors.New("empty population")
+	}
+	if len(m.probs) != len(ga.Population[0].Genes) {
+		m.initProbabilities(ga.Population)
+	}
+
+	switch m.Config.Variant {
+	case CompactGA:
+		m.compact(ga)
+	case UMDA:
+		m.umda(ga)
+	default:
+		m.pbil(ga)
+	}
+
+	ga.SetLocalBest(ga.GetBestChromosome())
+	ga.adaptRates()
+	ga.checkStagnation()
+	ga.CurrentGeneration++
+	ga.Logger.LogGeneration(ga)
+	ga.Logger.LogInfo("Распределение %v: энтропия=%.3f бит на ген", m.Config.Variant, m.entropy())
+
+	if ga.ShouldTerminate() {
+		ga.Logger.LogCompletion(ga)
+	}
+
+	return nil
+}
+
+// nextPopulation заменяет популяцию элитой и новыми особями из распределения
+func (m *EDAEvolutionModel) nextPopulation(ga *Algorithm) {
+	elites := ga.getElites()
+	next := make([]Chromosome, 0, ga.PopulationSize)
+	for _, e := range elites {
+		next = append(next, copyChromosome(e))
+	}
+	for len(next) < ga.PopulationSize {
+		next = append(next, m.sample(ga))
+	}
+	ga.Population = next
+}
+
+// pbil сдвигает вектор к Learners лучшим особям популяции и сэмплирует новую популяцию
+func (m *EDAEvolutionModel) pbil(ga *Algorithm) {
+	rate := m.Config.LearningRate
+	if rate == 0 {
+		rate = 0.1
+	}
+	learners := max(m.Config.Learners, 1)
+	sorted := make([]Chromosome, len(ga.Population))
+	copy(sorted, ga.Population)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Fitness > sorted[j].Fitness })
+	for _, c := range sorted[:min(learners, len(sorted))] {
+		for i, on := range c.Genes {
+			target := 0.0
+			if on {
+				target = 1
+			}
+			m.probs[i] = (1-rate)*m.probs[i] + rate*target
+		}
+	}
+	for i := range m.probs {
+		m.probs[i] = clampProbability(m.probs[i], len(m.probs))
+	}
+	m.nextPopulation(ga)
+}
+
+// umda оценивает вектор по частотам генов у особей, отобранных стратегией селекции
+func (m *EDAEvolutionModel) umda(ga *Algorithm) {
+	ratio := m.Config.SelectionRatio
+	if ratio == 0 {
+		ratio = 0.5
+	}
+	selected := ga.selectParents(ga.Population, max(int(ratio*float64(len(ga.Population))), 1))
+	counts := make([]float64, len(m.probs))
+	for _, c := range selected {
+		for i, on := range c.Genes {
+			if on {
+				counts[i]++
+			}
+		}
+	}
+	for i := range m.probs {
+		m.probs[i] = clampProbability(counts[i]/float64(len(selected)), len(m.probs))
+	}
+	m.nextPopulation(ga)
+}
+
+// compact проводит PopulationSize/2 соревнований пар; популяция поколения — все соревновавшиеся особи
+func (m *EDAEvolutionModel) compact(ga *Algorithm) {
+	step := 1 / float64(ga.PopulationSize)
+	next := make([]Chromosome, 0, ga.PopulationSize)
+	for len(next) < ga.PopulationSize {
+		a, b := m.sample(ga), m.sample(ga)
+		winner, loser := a, b
+		if b.Fitness > a.Fitness {
+			winner, loser = b, a
+		}
+		for i := range m.probs {
+			if winner.Genes[i] == loser.Genes[i] {
+				continue
+			}
+			if winner.Genes[i] {
+				m.probs[i] += step
+			} else {
+				m.probs[i] -= step
+			}
+			m.probs[i] = clampProbability(m.probs[i], len(m.probs))
+		}
+		next = append(next, winner)
+		if len(next) < ga.PopulationSize {
+			next = append(next, loser)
+		}
+	}
+	ga.Population = next
+}
+
+// entropy возвращает среднюю энтропию генов: 1 бит — равновероятно, 0 — вектор сошёлся
+func (m *EDAEvolutionModel) entropy() float64 {
+	if len(m.probs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, p := range m.probs {
+		if p > 0 && p < 1 {
+			sum -= p*math.Log2(p) + (1-p)*math.Log2(1-p)
+		}
+	}
+	return sum / float64(len(m.probs))
+}
+
+func (m *EDAEvolutionModel) GetRequiredStrategies() []string {
+	if m.Config.Variant == UMDA {
+		return []string{"Selection"}
+	}
+	return nil
+}
+
+func (m *EDAEvolutionModel) ValidateStrategies(ga *Algorithm) error {
+	if m.Config.Variant == UMDA && ga.SelectionStrategy.Strategy == nil {
+		return errors.New("selection strategy is required for UMDA")
+	}
+	return m.Config.Validate(ga.PopulationSize)
+}
+
+func (m *EDAEvolutionModel) GetModelName() string {
+	return "EDA"
+}
+
+func (m *EDAEvolutionModel) String() string {
+	return "EDA"
+}
+
+// GeneProbabilities возвращает копию вектора вероятностей модели оценки распределения
+// (по рёбрам для паросочетания, по вершинам для вершинных задач) и признак того, что модель — EDA
+func (ga *Algorithm) GeneProbabilities() ([]float64, bool) {
+	m, ok := ga.EvolutionModel.(*EDAEvolutionModel)
+	if !ok || m.probs == nil {
+		return nil, false
+	}
+	return append([]float64(nil), m.probs...), true
+}
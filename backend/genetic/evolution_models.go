@@ -3,7 +3,6 @@ package genetic
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 )
 
 // ClassicEvolutionModel реализует классический генетический алгоритм
@@ -26,26 +25,29 @@ func (m *ClassicEvolutionModel) Evolve(ga *Algorithm) error {
 	elites := ga.getElites()
 	newPop = append(newPop, elites...)
 	ga.Logger.LogDebug("Сохранено %d элитных особей", len(elites))
+	pool := ga.replacementPool(ga.Population)
+
+	// Selection: родители на всё поколение выбираются одним вызовом
+	parents := ga.selectParents(ga.Population, 2*(ga.PopulationSize-len(newPop)))
 
 	// Generate rest of population
-	for len(newPop) < ga.PopulationSize {
-		// Selection
-		p1 := ga.SelectionStrategy.Strategy.Select(ga.Population)
-		p2 := ga.SelectionStrategy.Strategy.Select(ga.Population)
+	for i := 0; len(newPop) < ga.PopulationSize; i += 2 {
+		p1, p2 := parents[i], parents[i+1]
 
 		// Crossover
 		child := ga.CrossoverStrategy.Crossover(p1, p2)
 
 		// Mutation
-		ga.MutationStrategy.Mutate(&child, ga.MutationRate, ga.Graph)
+		ga.mutate(&child, p1, p2)
 
 		// Repair if needed
-		RepairFast(&child, ga.Graph)
+		ga.repair(&child)
 
 		// Explicit fitness evaluation
-		Evaluate(&child, ga.Graph)
+		ga.evaluate(&child)
+		ga.reportOffspring(child)
 
-		newPop = append(newPop, child)
+		newPop = ga.acceptOffspring(newPop, pool, child, p1, p2)
 	}
 
 	// Ensure population size is maintained
@@ -56,8 +58,10 @@ func (m *ClassicEvolutionModel) Evolve(ga *Algorithm) error {
 		return err
 	}
 
-	ga.Population = newPop
+	ga.Population = survivors(newPop, pool)
 	ga.SetLocalBest(ga.GetBestChromosome())
+	ga.adaptRates()
+	ga.checkStagnation()
 	ga.CurrentGeneration++
 
 	// Log generation information
@@ -100,21 +104,50 @@ func (m *ClassicEvolutionModel) String() string {
 }
 
 // IslandEvolutionModel реализует островную модель генетического алгоритма
-// Разделяет популяцию на изолированные подпопуляции (острова)
-// Периодически обменивается особями между островами
-type IslandEvolutionModel struct{}
+// Разделяет популяцию на изолированные подпопуляции (острова), которые сохраняются между поколениями
+// Периодически обменивается особями между островами по заданной топологии
+type IslandEvolutionModel struct {
+	Config IslandConfig // Топология и политика миграции
+
+	operators []IslandOperators // Операторы островов с применёнными собственными вероятностями
+}
+
+func (m *IslandEvolutionModel) topology() IslandTopology {
+	if m.Config.Topology == nil {
+		return &RingTopology{}
+	}
+	return m.Config.Topology
+}
 
 func (m *IslandEvolutionModel) Evolve(ga *Algorithm) error {
-	islands := ga.DistributePopulation()
-	for i := range islands {
-		islands[i] = ga.EvolveIsland(islands[i])
+	// Острова создаются один раз и пересоздаются только при замене популяции извне
+	if len(ga.Islands) != ga.NumIslands || len(MergeIslands(ga.Islands)) != len(ga.Population) {
+		ga.ResetIslands()
 	}
-	if ga.CurrentGeneration%ga.MigrationInterval == 0 {
-		islands = MigrateIslands(islands)
+
+	topology := m.topology()
+	policy := m.Config.Migration
+	interval := ga.MigrationInterval
+
+	for i := range ga.Islands {
+		ga.Islands[i] = ga.evolveIslandWith(ga.Islands[i], m.islandOperators(ga, i))
+		// Асинхронно каждый остров мигрирует со своим сдвигом фазы, мигранты прибывают сразу
+		if policy.Async && (ga.CurrentGeneration+i)%interval == 0 {
+			migrateIsland(ga.Islands, i, topology, policy)
+		}
 	}
-	ga.Population = MergeIslands(islands)
+	if !policy.Async && ga.CurrentGeneration%interval == 0 {
+		MigrateIslandsWith(ga.Islands, topology, policy)
+	}
+
+	ga.Population = MergeIslands(ga.Islands)
+	ga.adaptRates()
+	ga.checkStagnation()
 	ga.CurrentGeneration++
 	ga.Logger.LogGeneration(ga)
+	if ga.CurrentGeneration%interval == 0 {
+		ga.Logger.LogIslandStats(ga.IslandStatistics())
+	}
 
 	// Check if we should terminate and log completion
 	if ga.ShouldTerminate() {
@@ -138,81 +171,42 @@ func (m *IslandEvolutionModel) ValidateStrategies(ga *Algorithm) error {
 	if ga.MutationStrategy == nil {
 		return errors.New("mutation strategy is required for island model")
 	}
-	return nil
-}
-
-func (m *IslandEvolutionModel) GetModelName() string {
-	return "Island"
-}
-
-func (m *IslandEvolutionModel) String() string {
-	return "Island"
-}
-
-// SteadyStateEvolutionModel реализует модель с постоянным состоянием
-// В каждом поколении заменяет только одну особь
-// Подходит для задач, где важно сохранять хорошие решения
-type SteadyStateEvolutionModel struct{}
-
-func (m *SteadyStateEvolutionModel) Evolve(ga *Algorithm) error {
-	p1 := ga.SelectionStrategy.Strategy.Select(ga.Population)
-	p2 := ga.SelectionStrategy.Strategy.Select(ga.Population)
-	child := ga.CrossoverStrategy.Crossover(p1, p2)
-	ga.MutationStrategy.Mutate(&child, ga.MutationRate, ga.Graph)
-	RepairFast(&child, ga.Graph)
-	Evaluate(&child, ga.Graph)
-
-	// Replace worst individual
-	worst := 0
-	for i, c := range ga.Population {
-		if c.Fitness < ga.Population[worst].Fitness {
-			worst = i
-		}
+	if ga.NumIslands < 1 {
+		return errors.New("number of islands must be at least 1")
 	}
-	if child.Fitness > ga.Population[worst].Fitness {
-		ga.Population[worst] = child
-		// Update bestSoFar if the new child is better
-		ga.SetBestSoFar(child)
+	if ga.MigrationInterval < 1 {
+		return errors.New("migration interval must be at least 1")
 	}
-	ga.CurrentGeneration++
-	ga.Logger.LogGeneration(ga)
-
-	// Check if we should terminate and log completion
-	if ga.ShouldTerminate() {
-		ga.Logger.LogCompletion(ga)
-	}
-
-	return nil
-}
-
-func (m *SteadyStateEvolutionModel) GetRequiredStrategies() []string {
-	return []string{"Selection", "Crossover", "Mutation"}
-}
-
-func (m *SteadyStateEvolutionModel) ValidateStrategies(ga *Algorithm) error {
-	if ga.SelectionStrategy.Strategy == nil {
-		return errors.New("selection strategy is required for steady state model")
+	if len(m.Config.Operators) > ga.NumIslands {
+		return fmt.Errorf("operators configured for %d islands, but only %d islands exist",
+			len(m.Config.Operators), ga.NumIslands)
 	}
-	if ga.CrossoverStrategy == nil {
-		return errors.New("crossover strategy is required for steady state model")
+	for i, ops := range m.Config.Operators {
+		for _, rate := range []*float64{ops.MutationRate, ops.CrossoverRate} {
+			if rate != nil && (*rate < 0 || *rate > 1) {
+				return fmt.Errorf("island %d: rates must be in [0, 1], got %v", i, *rate)
+			}
+		}
 	}
-	if ga.MutationStrategy == nil {
-		return errors.New("mutation strategy is required for steady state model")
+	if custom, ok := m.Config.Topology.(*CustomTopology); ok {
+		if err := custom.Validate(ga.NumIslands); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (m *SteadyStateEvolutionModel) GetModelName() string {
-	return "SteadyState"
+func (m *IslandEvolutionModel) GetModelName() string {
+	return "Island"
 }
 
-func (m *SteadyStateEvolutionModel) String() string {
-	return "SteadyState"
+func (m *IslandEvolutionModel) String() string {
+	return "Island"
 }
 
 // MemeticEvolutionModel реализует меметический алгоритм
 // Комбинирует генетический алгоритм с локальным поиском
-// Применяет локальное улучшение к каждой новой особи
+// Применяет локальный поиск (ModelConfig.LocalSearch) к заданной доле новых особей
 type MemeticEvolutionModel struct{}
 
 func (m *MemeticEvolutionModel) Evolve(ga *Algorithm) error {
@@ -221,22 +215,25 @@ func (m *MemeticEvolutionModel) Evolve(ga *Algorithm) error {
 	// Elitism
 	elites := ga.GetAllBestChromosomes()
 	newPop = append(newPop, elites...)
+	pool := ga.replacementPool(ga.Population)
 
 	// Generate rest through memetic loop
-	for len(newPop) < ga.PopulationSize {
-		p1 := ga.SelectionStrategy.Strategy.Select(ga.Population)
-		p2 := ga.SelectionStrategy.Strategy.Select(ga.Population)
+	parents := ga.selectParents(ga.Population, 2*(ga.PopulationSize-len(newPop)))
+	for i := 0; len(newPop) < ga.PopulationSize; i += 2 {
+		p1, p2 := parents[i], parents[i+1]
 		child := ga.CrossoverStrategy.Crossover(p1, p2)
-		ga.MutationStrategy.Mutate(&child, ga.MutationRate, ga.Graph)
-		// Local search: one iteration of augmenting path
-		ApplyAugmentingPath(child.Genes, ga.Graph)
-		RepairFast(&child, ga.Graph)
-		Evaluate(&child, ga.Graph)
-		newPop = append(newPop, child)
+		ga.mutate(&child, p1, p2)
+		ga.repair(&child)
+		ga.evaluate(&child)
+		ga.applyLocalSearch(&child)
+		ga.reportOffspring(child)
+		newPop = ga.acceptOffspring(newPop, pool, child, p1, p2)
 	}
 
-	ga.Population = newPop
+	ga.Population = survivors(newPop, pool)
 	ga.SetLocalBest(ga.GetBestChromosome())
+	ga.adaptRates()
+	ga.checkStagnation()
 	ga.CurrentGeneration++
 	ga.Logger.LogGeneration(ga)
 
@@ -262,6 +259,9 @@ func (m *MemeticEvolutionModel) ValidateStrategies(ga *Algorithm) error {
 	if ga.MutationStrategy == nil {
 		return errors.New("mutation strategy is required for memetic model")
 	}
+	if f := ga.ModelConfig.LocalSearch.Fraction; f < 0 || f > 1 {
+		return fmt.Errorf("local search fraction must be in [0, 1], got %v", f)
+	}
 	return nil
 }
 
@@ -286,43 +286,50 @@ func (m *CombinedEvolutionModel) Evolve(ga *Algorithm) error {
 	// Elitism
 	elites := ga.getElites()
 	newPop = append(newPop, elites...)
+	pool := ga.replacementPool(ga.Population)
 
 	// Generate rest of population
 	for len(newPop) < ga.PopulationSize {
-		var child Chromosome
+		var child, p1, p2 Chromosome
 
 		// Selection
 		if m.Config.UseSelection {
-			p1 := ga.SelectionStrategy.Strategy.Select(ga.Population)
-			p2 := ga.SelectionStrategy.Strategy.Select(ga.Population)
+			parents := ga.selectParents(ga.Population, 2)
+			p1, p2 = parents[0], parents[1]
 			child = ga.CrossoverStrategy.Crossover(p1, p2)
 		} else {
 			// Random selection if no selection strategy
-			child = ga.Population[rand.Intn(len(ga.Population))]
+			p1 = ga.Population[rng.Intn(len(ga.Population))]
+			p2 = p1
+			child = copyChromosome(p1)
 		}
 
 		// Crossover
 		if m.Config.UseCrossover {
-			p2 := ga.SelectionStrategy.Strategy.Select(ga.Population)
+			p2 = ga.selectParents(ga.Population, 1)[0]
 			child = ga.CrossoverStrategy.Crossover(child, p2)
 		}
 
 		// Mutation
 		if m.Config.UseMutation {
-			ga.MutationStrategy.Mutate(&child, ga.MutationRate, ga.Graph)
+			ga.mutate(&child, p1, p2)
 		}
 
+		ga.repair(&child)
+		ga.evaluate(&child)
+
 		// Local search
 		if m.Config.UseLocalSearch {
-			ApplyAugmentingPath(child.Genes, ga.Graph)
+			ga.applyLocalSearch(&child)
 		}
-
-		RepairFast(&child, ga.Graph)
-		newPop = append(newPop, child)
+		ga.reportOffspring(child)
+		newPop = ga.acceptOffspring(newPop, pool, child, p1, p2)
 	}
 
-	ga.Population = newPop
+	ga.Population = survivors(newPop, pool)
 	ga.SetLocalBest(ga.GetBestChromosome())
+	ga.adaptRates()
+	ga.checkStagnation()
 	ga.CurrentGeneration++
 	ga.Logger.LogGeneration(ga)
 
@@ -339,7 +346,8 @@ func (m *CombinedEvolutionModel) GetRequiredStrategies() []string {
 	if m.Config.UseSelection {
 		strategies = append(strategies, "Selection")
 	}
-	if m.Config.UseCrossover {
+	// Этап селекции скрещивает выбранных родителей, поэтому ему тоже нужен кроссовер
+	if m.Config.UseSelection || m.Config.UseCrossover {
 		strategies = append(strategies, "Crossover")
 	}
 	if m.Config.UseMutation {
@@ -352,7 +360,7 @@ func (m *CombinedEvolutionModel) ValidateStrategies(ga *Algorithm) error {
 	if m.Config.UseSelection && ga.SelectionStrategy.Strategy == nil {
 		return errors.New("selection strategy is required but not provided")
 	}
-	if m.Config.UseCrossover && ga.CrossoverStrategy == nil {
+	if (m.Config.UseSelection || m.Config.UseCrossover) && ga.CrossoverStrategy == nil {
 		return errors.New("crossover strategy is required but not provided")
 	}
 	if m.Config.UseMutation && ga.MutationStrategy == nil {
@@ -375,13 +383,25 @@ func NewEvolutionModelStrategy(config EvolutionModelConfig) (EvolutionModelStrat
 	case Classic:
 		return &ClassicEvolutionModel{}, nil
 	case Island:
-		return &IslandEvolutionModel{}, nil
+		return &IslandEvolutionModel{Config: config.Islands}, nil
 	case SteadyState:
-		return &SteadyStateEvolutionModel{}, nil
+		return &SteadyStateEvolutionModel{Config: config.SteadyState}, nil
 	case Memetic:
 		return &MemeticEvolutionModel{}, nil
 	case Combined:
 		return &CombinedEvolutionModel{Config: config}, nil
+	case NSGA2:
+		return &NSGA2EvolutionModel{Config: config.MultiObjective}, nil
+	case Cellular:
+		return &CellularEvolutionModel{Config: config.Cellular}, nil
+	case EDA:
+		return &EDAEvolutionModel{Config: config.EDA}, nil
+	case SimulatedAnnealing:
+		return &AnnealingModel{Config: config.Annealing}, nil
+	case TabuSearch:
+		return &TabuModel{Config: config.Tabu}, nil
+	case AntColony:
+		return &ACOModel{Config: config.ACO}, nil
 	default:
 		return nil, fmt.Errorf("unsupported evolution model: %v", config.Model)
 	}
@@ -6,46 +6,57 @@ func (ga *Algorithm) EvolvePopulation() {
 
 	elites := ga.getElites()
 	newPopulation = append(newPopulation, elites...)
+	pool := ga.replacementPool(ga.Population)
 
-	for len(newPopulation) < ga.PopulationSize {
-		parent1 := ga.SelectionStrategy.Strategy.Select(ga.Population)
-		parent2 := ga.SelectionStrategy.Strategy.Select(ga.Population)
+	parents := ga.selectParents(ga.Population, 2*(ga.PopulationSize-len(newPopulation)))
+	for i := 0; len(newPopulation) < ga.PopulationSize; i += 2 {
+		parent1, parent2 := parents[i], parents[i+1]
 
 		child := ga.CrossoverStrategy.Crossover(parent1, parent2)
 
 		// Применяем мутацию через стратегию
-		ga.MutationStrategy.Mutate(&child, ga.MutationRate, ga.Graph)
+		ga.mutate(&child, parent1, parent2)
 
-		RepairFast(&child, ga.Graph)
+		ga.repair(&child)
 		//EvaluateFast(&child, ga.Graph)
-		Evaluate(&child, ga.Graph)
-		newPopulation = append(newPopulation, child)
+		ga.evaluate(&child)
+		ga.reportOffspring(child)
+		newPopulation = ga.acceptOffspring(newPopulation, pool, child, parent1, parent2)
 	}
 
-	ga.Population = newPopulation
+	ga.Population = survivors(newPopulation, pool)
 
 }
 
+// EvolveIsland выполняет один шаг эволюции острова с глобальными операторами алгоритма
 func (ga *Algorithm) EvolveIsland(island []Chromosome) []Chromosome {
+	return ga.evolveIslandWith(island, ga.globalOperators())
+}
+
+// evolveIslandWith выполняет один шаг эволюции острова с заданным набором операторов
+func (ga *Algorithm) evolveIslandWith(island []Chromosome, ops resolvedOperators) []Chromosome {
 	newPopulation := make([]Chromosome, 0, len(island))
 
 	// Добавляем элиту
-	elites := ga.getElitesFromIsland(island, ga.SelectionStrategy.EliteSize)
+	elites := ga.getElitesFromIsland(island, ops.eliteSize)
 	newPopulation = append(newPopulation, elites...)
+	pool := ga.replacementPool(island)
 
-	for len(newPopulation) < len(island) {
-		parent1 := ga.SelectionStrategy.Strategy.Select(island)
-		parent2 := ga.SelectionStrategy.Strategy.Select(island)
+	parents := ga.selectParentsWith(ops.selection, island, 2*(len(island)-len(newPopulation)))
+	for i := 0; len(newPopulation) < len(island); i += 2 {
+		parent1, parent2 := parents[i], parents[i+1]
 
-		child := ga.CrossoverStrategy.Crossover(parent1, parent2)
+		child := ops.crossover.Crossover(parent1, parent2)
 
 		// Применяем мутацию через стратегию
-		ga.MutationStrategy.Mutate(&child, ga.MutationRate, ga.Graph)
+		ga.mutateWith(ops.mutation, ops.mutationRate, &child, parent1, parent2)
 
-		RepairFast(&child, ga.Graph)
+		ga.repair(&child)
 		//EvaluateFast(&child, ga.Graph)
-		Evaluate(&child, ga.Graph)
-		newPopulation = append(newPopulation, child)
+		ga.evaluate(&child)
+		reportOffspringTo(ops.crossover, child)
+		ga.observeOffspring(child)
+		newPopulation = ga.acceptOffspring(newPopulation, pool, child, parent1, parent2)
 	}
-	return newPopulation
+	return survivors(newPopulation, pool)
 }
@@ -0,0 +1,183 @@
+package genetic
+
+import (
+	"container/list"
+	"sync"
+)
+
+// defaultCacheSize — ёмкость кэша приспособленности по умолчанию
+const defaultCacheSize = 4096
+
+// CacheStats содержит статистику кэша приспособленности
+type CacheStats struct {
+	Hits      int // Сколько оценок взято из кэша
+	Misses    int // Сколько оценок пришлось вычислить
+	Evictions int // Сколько записей вытеснено
+	Size      int // Текущее число записей
+}
+
+// HitRate возвращает долю попаданий
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// cacheEntry — запись кэша; упакованный геном хранится для проверки коллизий хэша
+type cacheEntry struct {
+	key     uint64
+	packed  []uint64
+	fitness int
+}
+
+// FitnessCache — ограниченный LRU-кэш приспособленности, безопасный для параллельного использования.
+// Ключ — 64-битный хэш генома, упакованного в машинные слова.
+type FitnessCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Начало списка — самые свежие записи
+	entries  map[uint64][]*list.Element
+	stats    CacheStats
+}
+
+// NewFitnessCache создаёт кэш на capacity записей (0 — ёмкость по умолчанию)
+func NewFitnessCache(capacity int) *FitnessCache {
+	if capacity <= 0 {
+		capacity = defaultCacheSize
+	}
+	return &FitnessCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[uint64][]*list.Element),
+	}
+}
+
+// packGenes упаковывает гены по 64 в слово
+func packGenes(genes []bool) []uint64 {
+	packed := make([]uint64, (len(genes)+63)/64)
+	for i, g := range genes {
+		if g {
+			packed[i/64] |= 1 << (i % 64)
+		}
+	}
+	return packed
+}
+
+// hashPacked вычисляет FNV-1a по словам упакованного генома
+func hashPacked(packed []uint64, length int) uint64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	h := uint64(offset) ^ uint64(length)
+	for _, w := range packed {
+		h ^= w
+		h *= prime
+		h ^= h >> 29
+	}
+	return h
+}
+
+func equalPacked(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Get возвращает сохранённую приспособленность генома
+func (c *FitnessCache) Get(genes []bool) (int, bool) {
+	packed := packGenes(genes)
+	key := hashPacked(packed, len(genes))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, el := range c.entries[key] {
+		if e := el.Value.(*cacheEntry); equalPacked(e.packed, packed) {
+			c.order.MoveToFront(el)
+			c.stats.Hits++
+			return e.fitness, true
+		}
+	}
+	c.stats.Misses++
+	return 0, false
+}
+
+// Put сохраняет приспособленность генома, вытесняя самую старую запись при переполнении
+func (c *FitnessCache) Put(genes []bool, fitness int) {
+	packed := packGenes(genes)
+	key := hashPacked(packed, len(genes))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, el := range c.entries[key] {
+		if e := el.Value.(*cacheEntry); equalPacked(e.packed, packed) {
+			e.fitness = fitness
+			c.order.MoveToFront(el)
+			return
+		}
+	}
+
+	el := c.order.PushFront(&cacheEntry{key: key, packed: packed, fitness: fitness})
+	c.entries[key] = append(c.entries[key], el)
+	if c.order.Len() > c.capacity {
+		c.evictOldest()
+	}
+}
+
+func (c *FitnessCache) evictOldest() {
+	el := c.order.Back()
+	c.order.Remove(el)
+	e := el.Value.(*cacheEntry)
+	bucket := c.entries[e.key]
+	for i, other := range bucket {
+		if other == el {
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			break
+		}
+	}
+	if len(bucket) == 0 {
+		delete(c.entries, e.key)
+	} else {
+		c.entries[e.key] = bucket
+	}
+	c.stats.Evictions++
+}
+
+// Stats возвращает накопленную статистику кэша
+func (c *FitnessCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+// evaluate оценивает потомка функцией задачи, используя кэш, если он включён в Config
+func (ga *Algorithm) evaluate(chrom *Chromosome) {
+	if ga.fitnessCache == nil {
+		ga.Problem.Evaluate(chrom)
+		return
+	}
+	if fitness, ok := ga.fitnessCache.Get(chrom.Genes); ok {
+		chrom.Fitness = fitness
+		return
+	}
+	ga.Problem.Evaluate(chrom)
+	ga.fitnessCache.Put(chrom.Genes, chrom.Fitness)
+}
+
+// CacheStats возвращает статистику кэша приспособленности и признак того, что кэш включён
+func (ga *Algorithm) CacheStats() (CacheStats, bool) {
+	if ga.fitnessCache == nil {
+		return CacheStats{}, false
+	}
+	return ga.fitnessCache.Stats(), true
+}
@@ -16,6 +16,9 @@ type GraphModel struct {
 	NumVertices int
 	Edges       []Edge
 	Positions   []Point2D // len == NumVertices
+	Costs       []float64 // Стоимости рёбер (nil — невзвешенный граф)
+	Capacities  []int     // Ёмкости вершин для b-паросочетания (nil — все равны 1)
+	Preferences [][]int   // Списки предпочтений вершин для устойчивого паросочетания (nil — нет)
 }
 
 // NewGraphModel создаёт пустую модель графа с n вершинами.
@@ -40,9 +43,50 @@ func (gm *GraphModel) AddEdge(u, v int) {
 	gm.Edges = append(gm.Edges, Edge{U: u, V: v})
 }
 
+// AddWeightedEdge добавляет ребро со стоимостью cost. Рёбра без стоимости, добавленные ранее,
+// получают нулевую стоимость.
+func (gm *GraphModel) AddWeightedEdge(u, v int, cost float64) {
+	n := len(gm.Edges)
+	gm.AddEdge(u, v)
+	if len(gm.Edges) == n {
+		return
+	}
+	for len(gm.Costs) < n {
+		gm.Costs = append(gm.Costs, 0)
+	}
+	gm.Costs = append(gm.Costs, cost)
+}
+
+// SetCapacity задаёт ёмкость вершины v; остальные вершины сохраняют ёмкость 1.
+func (gm *GraphModel) SetCapacity(v, capacity int) {
+	if v < 0 || v >= gm.NumVertices || capacity < 0 {
+		return
+	}
+	if gm.Capacities == nil {
+		gm.Capacities = make([]int, gm.NumVertices)
+		for i := range gm.Capacities {
+			gm.Capacities[i] = 1
+		}
+	}
+	gm.Capacities[v] = capacity
+}
+
+// RandomizePreferences задаёт каждой вершине случайный порядок предпочтения всех её соседей.
+func (gm *GraphModel) RandomizePreferences() {
+	gm.Preferences = make([][]int, gm.NumVertices)
+	for _, e := range gm.Edges {
+		gm.Preferences[e.U] = append(gm.Preferences[e.U], e.V)
+		gm.Preferences[e.V] = append(gm.Preferences[e.V], e.U)
+	}
+	for _, list := range gm.Preferences {
+		rand.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+	}
+}
+
 // ToGraph конвертирует модель в Graph для запуска алгоритма.
 func (gm *GraphModel) ToGraph() Graph {
-	return Graph{NumVertices: gm.NumVertices, Edges: gm.Edges}
+	return Graph{NumVertices: gm.NumVertices, Edges: gm.Edges, Costs: gm.Costs, Capacities: gm.Capacities,
+		Preferences: gm.Preferences}
 }
 
 // PredefinedGraphs возвращает карту всех шаблонных графов с корректными Positions.
@@ -393,6 +437,46 @@ func PredefinedGraphs() map[string]*GraphModel {
 		graphs[fmt.Sprintf("Great random (%d edges)", n)] = r
 	}
 
+	// 5) Задачи о назначениях со случайными стоимостями
+	for _, size := range [][2]int{{8, 8}, {20, 15}} {
+		rows, cols := size[0], size[1]
+		costs := make([][]float64, rows)
+		for i := range costs {
+			costs[i] = make([]float64, cols)
+			for j := range costs[i] {
+				if rand.Float64() < 0.3 {
+					costs[i][j] = math.Inf(1) // нет ребра
+					continue
+				}
+				costs[i][j] = float64(1 + rand.Intn(99))
+			}
+		}
+		if a, err := NewAssignmentModel(costs); err == nil {
+			graphs[fmt.Sprintf("Assignment %dx%d (random costs)", rows, cols)] = a
+		}
+	}
+
+	// 6) Устойчивые паросочетания: полные списки на полном двудольном графе и неполные списки
+	for _, preset := range []struct {
+		name    string
+		density float64
+	}{
+		{"Stable Marriage 10x10 (complete lists)", 1},
+		{"Stable Marriage 10x10 (incomplete lists)", 0.5},
+	} {
+		const side = 10
+		sm := newBipartiteModel(side, side)
+		for i := 0; i < side; i++ {
+			for j := 0; j < side; j++ {
+				if rand.Float64() < preset.density {
+					sm.AddEdge(i, side+j)
+				}
+			}
+		}
+		sm.RandomizePreferences()
+		graphs[preset.name] = sm
+	}
+
 	return graphs
 }
 
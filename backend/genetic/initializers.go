@@ -0,0 +1,295 @@
+package genetic
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Initializer строит допустимую хромосому для начальной популяции
+type Initializer interface {
+	Generate(graph *Graph) Chromosome
+	GetName() string
+}
+
+// WeightedInitializer — инициализатор и его доля в смеси
+type WeightedInitializer struct {
+	Initializer Initializer
+	Weight      float64
+}
+
+// InitializationConfig содержит настройки построения начальной популяции
+type InitializationConfig struct {
+	Mix          []WeightedInitializer // Смесь инициализаторов (пусто — случайные гены с починкой по индексу)
+	Seeds        [][]bool              // Заранее известные паросочетания (геномы)
+	SeedFraction float64               // Доля популяции, засеваемая копиями Seeds
+}
+
+// Validate проверяет веса смеси и длину затравочных геномов
+func (c InitializationConfig) Validate(numEdges int) error {
+	for _, w := range c.Mix {
+		if w.Initializer == nil {
+			return fmt.Errorf("initializer mix contains nil initializer")
+		}
+		if w.Weight < 0 {
+			return fmt.Errorf("initializer %s has negative weight %v", w.Initializer.GetName(), w.Weight)
+		}
+	}
+	if c.SeedFraction < 0 || c.SeedFraction > 1 {
+		return fmt.Errorf("seed fraction must be in [0, 1], got %v", c.SeedFraction)
+	}
+	for i, s := range c.Seeds {
+		if len(s) != numEdges {
+			return fmt.Errorf("seed %d has %d genes, graph has %d edges", i, len(s), numEdges)
+		}
+	}
+	return nil
+}
+
+// pick выбирает инициализатор пропорционально весам
+func (c InitializationConfig) pick() Initializer {
+	total := 0.0
+	for _, w := range c.Mix {
+		total += w.Weight
+	}
+	if total <= 0 {
+		return &RandomInitializer{}
+	}
+	r := rng.Float64() * total
+	for _, w := range c.Mix {
+		if r < w.Weight {
+			return w.Initializer
+		}
+		r -= w.Weight
+	}
+	return c.Mix[len(c.Mix)-1].Initializer
+}
+
+// seedCount возвращает число затравочных особей в популяции размера size
+func (c InitializationConfig) seedCount(size int) int {
+	if len(c.Seeds) == 0 {
+		return 0
+	}
+	return int(math.Round(c.SeedFraction * float64(size)))
+}
+
+// ------------------------ Случайные гены ------------------------ //
+
+// RandomInitializer включает каждое ребро с вероятностью Density и чинит хромосому.
+// Малая плотность уменьшает число конфликтов, а значит и перекос починки в пользу рёбер с малыми индексами.
+type RandomInitializer struct {
+	Density       float64 // Вероятность включения ребра (0 — 0.5)
+	ShuffleRepair bool    // Чинить в случайном порядке рёбер вместо порядка индексов
+}
+
+func (i *RandomInitializer) Generate(graph *Graph) Chromosome {
+	density := i.Density
+	if density <= 0 {
+		density = 0.5
+	}
+	genes := make([]bool, len(graph.Edges))
+	for j := range genes {
+		genes[j] = rng.Float64() < density
+	}
+	chrom := Chromosome{Genes: genes}
+	if i.ShuffleRepair {
+		Repair(&chrom, graph)
+	} else {
+		RepairFast(&chrom, graph)
+	}
+	Evaluate(&chrom, graph)
+	return chrom
+}
+
+func (i *RandomInitializer) GetName() string {
+	if i.Density > 0 && i.Density < 0.5 {
+		return "SparseRandom"
+	}
+	return "Random"
+}
+
+// --------------------- Рандомизированный жадный --------------------- //
+
+// GreedyInitializer строит максимальное (по включению) паросочетание,
+// перебирая рёбра в случайном порядке
+type GreedyInitializer struct{}
+
+func (i *GreedyInitializer) Generate(graph *Graph) Chromosome {
+	chrom := Chromosome{Genes: make([]bool, len(graph.Edges))}
+	load := newVertexLoad(graph)
+	for _, idx := range rng.Perm(len(graph.Edges)) {
+		e := graph.Edges[idx]
+		if e.U != e.V && load.fits(e) {
+			load.add(e)
+			chrom.Genes[idx] = true
+		}
+	}
+	Evaluate(&chrom, graph)
+	return chrom
+}
+
+func (i *GreedyInitializer) GetName() string {
+	return "Greedy"
+}
+
+// ---------------------- Жадный по мин. степени ---------------------- //
+
+// MinDegreeInitializer реализует жадный алгоритм в духе Карпа–Сипсера:
+// на каждом шаге берётся свободная вершина минимальной остаточной степени
+// (висячие вершины — в первую очередь) и сочетается с соседом минимальной степени.
+// Вершина выбывает, когда исчерпана её ёмкость.
+// Равные степени разрешаются случайно.
+type MinDegreeInitializer struct{}
+
+func (i *MinDegreeInitializer) Generate(graph *Graph) Chromosome {
+	n := graph.NumVertices
+	chrom := Chromosome{Genes: make([]bool, len(graph.Edges))}
+	adj := make([][]incidence, n)
+	for idx, e := range graph.Edges {
+		if e.U == e.V {
+			continue
+		}
+		adj[e.U] = append(adj[e.U], incidence{to: e.V, edge: idx})
+		adj[e.V] = append(adj[e.V], incidence{to: e.U, edge: idx})
+	}
+
+	// Остаточная степень — число доступных рёбер: ещё не выбранных и не ведущих
+	// в вершину с исчерпанной ёмкостью (кратные рёбра учитываются)
+	degree := make([]int, n)
+	available := make([]bool, len(graph.Edges))
+	for v := range adj {
+		degree[v] = len(adj[v])
+		for _, inc := range adj[v] {
+			available[inc.edge] = true
+		}
+	}
+	load := newVertexLoad(graph)
+	order := rng.Perm(n)
+	drop := func(idx int) {
+		if !available[idx] {
+			return
+		}
+		available[idx] = false
+		e := graph.Edges[idx]
+		degree[e.U]--
+		degree[e.V]--
+	}
+	take := func(v int) {
+		for _, inc := range adj[v] {
+			drop(inc.edge)
+		}
+	}
+	for v := range adj {
+		if load.full(v) {
+			take(v)
+		}
+	}
+
+	for {
+		u := -1
+		for _, v := range order {
+			if degree[v] > 0 && (u < 0 || degree[v] < degree[u]) {
+				u = v
+				if degree[v] == 1 {
+					break
+				}
+			}
+		}
+		if u < 0 {
+			break
+		}
+
+		best := incidence{to: -1}
+		ties := 0
+		for _, inc := range adj[u] {
+			if !available[inc.edge] {
+				continue
+			}
+			switch {
+			case best.to < 0 || degree[inc.to] < degree[best.to]:
+				best, ties = inc, 1
+			case degree[inc.to] == degree[best.to]:
+				// Случайный выбор среди равных по степени (reservoir sampling)
+				ties++
+				if rng.Intn(ties) == 0 {
+					best = inc
+				}
+			}
+		}
+		chrom.Genes[best.edge] = true
+		load.add(graph.Edges[best.edge])
+		drop(best.edge)
+		for _, v := range []int{u, best.to} {
+			if load.full(v) {
+				take(v)
+			}
+		}
+	}
+
+	Evaluate(&chrom, graph)
+	return chrom
+}
+
+func (i *MinDegreeInitializer) GetName() string {
+	return "MinDegree"
+}
+
+// ------------------ Сохранение паросочетаний ------------------ //
+
+// savedMatching — формат файла паросочетания: рёбра задаются парами вершин,
+// чтобы файл оставался пригодным при изменении порядка рёбер графа
+type savedMatching struct {
+	NumVertices int      `json:"num_vertices"`
+	Edges       [][2]int `json:"edges"`
+}
+
+// SaveMatching сохраняет рёбра паросочетания genes в JSON-файл
+func SaveMatching(path string, graph *Graph, genes []bool) error {
+	if len(genes) != len(graph.Edges) {
+		return fmt.Errorf("matching has %d genes, graph has %d edges", len(genes), len(graph.Edges))
+	}
+	sm := savedMatching{NumVertices: graph.NumVertices}
+	for i, on := range genes {
+		if on {
+			e := graph.Edges[i]
+			sm.Edges = append(sm.Edges, [2]int{e.U, e.V})
+		}
+	}
+	data, err := json.MarshalIndent(sm, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadMatching читает паросочетание из JSON-файла и возвращает геном для graph.
+// Рёбра, отсутствующие в графе, приводят к ошибке.
+func LoadMatching(path string, graph *Graph) ([]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sm savedMatching
+	if err := json.Unmarshal(data, &sm); err != nil {
+		return nil, fmt.Errorf("parse matching %s: %w", path, err)
+	}
+	if sm.NumVertices != graph.NumVertices {
+		return nil, fmt.Errorf("matching is for %d vertices, graph has %d", sm.NumVertices, graph.NumVertices)
+	}
+
+	index := make(map[[2]int]int, len(graph.Edges))
+	for i, e := range graph.Edges {
+		index[[2]int{e.U, e.V}] = i
+		index[[2]int{e.V, e.U}] = i
+	}
+	genes := make([]bool, len(graph.Edges))
+	for _, pair := range sm.Edges {
+		idx, ok := index[pair]
+		if !ok {
+			return nil, fmt.Errorf("matching edge %d-%d is not in the graph", pair[0], pair[1])
+		}
+		genes[idx] = true
+	}
+	return genes, nil
+}
@@ -0,0 +1,132 @@
+package genetic
+
+// IslandOperators задаёт собственные операторы и параметры острова.
+// Незаданные поля (nil или 0) берутся из глобальных настроек алгоритма.
+type IslandOperators struct {
+	Selection     SelectionStrategy
+	Crossover     CrossoverStrategy
+	Mutation      MutationStrategy
+	MutationRate  *float64 // nil — глобальная вероятность мутации
+	CrossoverRate *float64 // nil — глобальная вероятность кроссовера
+	EliteSize     int      // 0 — глобальный размер элиты
+}
+
+// IslandRate возвращает указатель на вероятность для полей MutationRate и CrossoverRate
+func IslandRate(rate float64) *float64 {
+	return &rate
+}
+
+// IslandStats содержит статистику одного острова
+type IslandStats struct {
+	Island         int
+	Size           int
+	BestFitness    int
+	AverageFitness float64
+	UniqueGenomes  int
+	Selection      string
+	Crossover      string
+	Mutation       string
+	MutationRate   float64
+}
+
+// resolvedOperators — итоговый набор операторов острова, в котором заданы все поля
+type resolvedOperators struct {
+	selection    SelectionStrategy
+	crossover    CrossoverStrategy
+	mutation     MutationStrategy
+	mutationRate float64
+	eliteSize    int
+}
+
+// globalOperators возвращает операторы алгоритма в виде набора острова
+func (ga *Algorithm) globalOperators() resolvedOperators {
+	return resolvedOperators{
+		selection:    ga.SelectionStrategy.Strategy,
+		crossover:    ga.CrossoverStrategy,
+		mutation:     ga.MutationStrategy,
+		mutationRate: ga.MutationRate,
+		eliteSize:    ga.SelectionStrategy.EliteSize,
+	}
+}
+
+// prepareIslandOperators один раз при создании островов применяет собственные вероятности
+// кроссовера. WithRate возвращает копию, поэтому экземпляр, общий для нескольких островов
+// или для острова и алгоритма, не меняется
+func prepareIslandOperators(cfg []IslandOperators) []IslandOperators {
+	prepared := make([]IslandOperators, len(cfg))
+	copy(prepared, cfg)
+	for i, ops := range prepared {
+		if ops.Crossover != nil && ops.CrossoverRate != nil {
+			prepared[i].Crossover = ops.Crossover.WithRate(*ops.CrossoverRate)
+		}
+	}
+	return prepared
+}
+
+// resolve дополняет подготовленные операторы острова текущими глобальными значениями.
+// Собственный кроссовер без собственной вероятности следует глобальной вероятности,
+// которая может меняться по расписанию
+func (ops IslandOperators) resolve(ga *Algorithm) resolvedOperators {
+	r := ga.globalOperators()
+	if ops.Selection != nil {
+		r.selection = ops.Selection
+	}
+	if ops.Crossover != nil {
+		r.crossover = ops.Crossover
+		if ops.CrossoverRate == nil {
+			r.crossover = ops.Crossover.WithRate(ga.CrossoverRate)
+		}
+	}
+	if ops.Mutation != nil {
+		r.mutation = ops.Mutation
+	}
+	if ops.MutationRate != nil {
+		r.mutationRate = *ops.MutationRate
+	}
+	if ops.EliteSize > 0 {
+		r.eliteSize = ops.EliteSize
+	}
+	return r
+}
+
+// islandOperators возвращает итоговый набор операторов острова i
+func (m *IslandEvolutionModel) islandOperators(ga *Algorithm, i int) resolvedOperators {
+	if m.operators == nil {
+		m.operators = prepareIslandOperators(m.Config.Operators)
+	}
+	if i < len(m.operators) {
+		return m.operators[i].resolve(ga)
+	}
+	return ga.globalOperators()
+}
+
+// IslandStatistics возвращает статистику по каждому острову островной модели
+func (ga *Algorithm) IslandStatistics() []IslandStats {
+	stats := make([]IslandStats, 0, len(ga.Islands))
+	model, _ := ga.EvolutionModel.(*IslandEvolutionModel)
+	for i, island := range ga.Islands {
+		if len(island) == 0 {
+			continue
+		}
+		ops := ga.globalOperators()
+		if model != nil {
+			ops = model.islandOperators(ga, i)
+		}
+		st := IslandStats{
+			Island:        i,
+			Size:          len(island),
+			BestFitness:   ga.GetIslandBest(island).Fitness,
+			UniqueGenomes: ComputeDiversity(island).UniqueGenomes,
+			Selection:     ops.selection.GetName(),
+			Crossover:     ops.crossover.GetName(),
+			Mutation:      ops.mutation.GetName(),
+			MutationRate:  ops.mutationRate,
+		}
+		for _, c := range island {
+			st.AverageFitness += float64(c.Fitness)
+		}
+		st.AverageFitness /= float64(len(island))
+		stats = append(stats, st)
+	}
+	return stats
+}
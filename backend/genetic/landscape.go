@@ -0,0 +1,443 @@
+package genetic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// Анализ ландшафта приспособленности задачи о паросочетании. Решения — допустимые
+// паросочетания (геномы после RepairFast), расстояние — число различающихся генов.
+// Метрики объясняют трудность графа: корреляция фитнеса с расстоянием до точного оптимума,
+// автокорреляция случайных блужданий под операторами мутации, доля нейтральных соседей
+// и число локальных оптимумов у малых графов.
+
+// ------------------------ Настройки ------------------------ //
+
+// LandscapeConfig задаёт объём анализа ландшафта
+type LandscapeConfig struct {
+	Samples         int                // Случайных решений для FDC и нейтральности (0 — 500)
+	Walks           int                // Блужданий на оператор мутации (0 — 5)
+	WalkLength      int                // Шагов в блуждании (0 — 1000)
+	MaxLag          int                // Наибольший сдвиг автокорреляции (0 — 20)
+	Mutations       []MutationStrategy // Операторы блужданий (nil — все операторы мутации)
+	ExhaustiveLimit int                // Наибольшее число рёбер для перебора локальных оптимумов (0 — 20)
+}
+
+// neutralityNeighbours ограничивает число проверяемых соседей одного решения
+const neutralityNeighbours = 50
+
+// exhaustiveCeiling — предел ExhaustiveLimit: перебор растёт как 2^рёбер
+const exhaustiveCeiling = 30
+
+// Validate проверяет настройки
+func (c LandscapeConfig) Validate() error {
+	if c.Samples < 0 || c.Walks < 0 || c.WalkLength < 0 || c.MaxLag < 0 {
+		return fmt.Errorf("landscape sample sizes must not be negative, got samples=%d walks=%d length=%d lag=%d",
+			c.Samples, c.Walks, c.WalkLength, c.MaxLag)
+	}
+	if c.ExhaustiveLimit < 0 || c.ExhaustiveLimit > exhaustiveCeiling {
+		return fmt.Errorf("exhaustive limit must be in [0, %d] edges, got %d", exhaustiveCeiling, c.ExhaustiveLimit)
+	}
+	if c.WalkLength > 0 && c.MaxLag >= c.WalkLength {
+		return fmt.Errorf("autocorrelation lag %d must be shorter than the walk length %d", c.MaxLag, c.WalkLength)
+	}
+	return nil
+}
+
+func (c LandscapeConfig) withDefaults() LandscapeConfig {
+	if c.Samples == 0 {
+		c.Samples = 500
+	}
+	if c.Walks == 0 {
+		c.Walks = 5
+	}
+	if c.WalkLength == 0 {
+		c.WalkLength = 1000
+	}
+	if c.MaxLag == 0 {
+		c.MaxLag = min(20, c.WalkLength-1)
+	}
+	if c.Mutations == nil {
+		c.Mutations = []MutationStrategy{
+			&ClassicMutationStrategy{},
+			&IslandMutationStrategy{},
+			&SteadyStateMutationStrategy{},
+			&ConflictAdaptiveMutationStrategy{},
+			&AugmentingPathMutationStrategy{},
+			&CombinedMutationStrategy{Strategies: []MutationStrategy{
+				&ClassicMutationStrategy{},
+				&AugmentingPathMutationStrategy{},
+			}},
+		}
+	}
+	if c.ExhaustiveLimit == 0 {
+		c.ExhaustiveLimit = 20
+	}
+	return c
+}
+
+// ------------------------ Результаты ------------------------ //
+
+// FDCPoint — случайное решение: расстояние до оптимума и фитнес
+type FDCPoint struct {
+	Distance int `json:"distance"`
+	Fitness  int `json:"fitness"`
+}
+
+// FDCResult — корреляция фитнеса с расстоянием до оптимума. Задача максимизируется,
+// поэтому корреляция около −1 означает, что фитнес ведёт к оптимуму, а около 0 и выше — что не ведёт
+type FDCResult struct {
+	Correlation float64    `json:"correlation"`
+	Points      []FDCPoint `json:"points"`
+}
+
+// WalkAutocorrelation — автокорреляция фитнеса вдоль случайных блужданий под одним оператором
+type WalkAutocorrelation struct {
+	Strategy          string    `json:"strategy"`
+	Rate              float64   `json:"rate"`               // Вероятность, переданная оператору
+	Autocorrelation   []float64 `json:"autocorrelation"`    // r(k) для сдвигов k = 0..MaxLag
+	CorrelationLength float64   `json:"correlation_length"` // −1/ln|r(1)|: чем больше, тем глаже ландшафт
+	Neutrality        float64   `json:"neutrality"`         // Доля изменивших геном шагов с тем же фитнесом
+	Stalled           float64   `json:"stalled"`            // Доля шагов, не изменивших геном
+}
+
+// NeutralityResult — доля соседей (переворот одного гена и починка) с тем же фитнесом
+type NeutralityResult struct {
+	Ratio      float64 `json:"ratio"`
+	Neighbours int     `json:"neighbours"`
+}
+
+// OptimaLevel — число локальных оптимумов с данным фитнесом
+type OptimaLevel struct {
+	Fitness int `json:"fitness"`
+	Count   int `json:"count"`
+}
+
+// LocalOptimaResult — полный перебор допустимых решений малого графа. Соседи решения —
+// решения, получаемые добавлением или удалением одного ребра; локальный оптимум не имеет
+// соседа со строго большим фитнесом
+type LocalOptimaResult struct {
+	Computed     bool          `json:"computed"` // false — граф больше ExhaustiveLimit рёбер
+	Solutions    int           `json:"solutions"`
+	LocalOptima  int           `json:"local_optima"`
+	GlobalOptima int           `json:"global_optima"`
+	Levels       []OptimaLevel `json:"levels"` // По убыванию фитнеса
+}
+
+// LandscapeReport — результаты анализа ландшафта графа
+type LandscapeReport struct {
+	Vertices       int                   `json:"vertices"`
+	Edges          int                   `json:"edges"`
+	OptimumFitness int                   `json:"optimum_fitness"`
+	OptimumEdges   int                   `json:"optimum_edges"`
+	FDC            FDCResult             `json:"fdc"`
+	Walks          []WalkAutocorrelation `json:"walks"`
+	Neutrality     NeutralityResult      `json:"neutrality"`
+	LocalOptima    LocalOptimaResult     `json:"local_optima"`
+}
+
+// ------------------------ Анализ ------------------------ //
+
+// AnalyzeLandscape вычисляет метрики ландшафта паросочетаний графа. Оптимум берётся
+// у точного решателя: венгерского алгоритма для взвешенного графа, иначе MaxBMatching
+func AnalyzeLandscape(graph *Graph, cfg LandscapeConfig) (LandscapeReport, error) {
+	if err := cfg.Validate(); err != nil {
+		return LandscapeReport{}, err
+	}
+	if len(graph.Edges) == 0 {
+		return LandscapeReport{}, errors.New("landscape analysis requires a graph with edges")
+	}
+	cfg = cfg.withDefaults()
+
+	optimum, err := exactOptimum(graph)
+	if err != nil {
+		return LandscapeReport{}, err
+	}
+	report := LandscapeReport{
+		Vertices:       graph.NumVertices,
+		Edges:          len(graph.Edges),
+		OptimumFitness: optimum.Fitness,
+		OptimumEdges:   len(ValidMatchingEdges(optimum.Genes, graph)),
+	}
+
+	samples := make([]Chromosome, cfg.Samples)
+	for i := range samples {
+		samples[i] = randomMatching(graph)
+	}
+	report.FDC = fitnessDistanceCorrelation(samples, optimum)
+	report.Neutrality = sampleNeutrality(samples, graph)
+	for _, strategy := range cfg.Mutations {
+		report.Walks = append(report.Walks, randomWalks(graph, strategy, cfg))
+	}
+	if len(graph.Edges) <= cfg.ExhaustiveLimit {
+		report.LocalOptima = enumerateLocalOptima(graph)
+	}
+	return report, nil
+}
+
+// exactOptimum возвращает оценённое оптимальное решение графа
+func exactOptimum(graph *Graph) (Chromosome, error) {
+	var genes []bool
+	if graph.Costs != nil {
+		solution, err := SolveAssignment(graph)
+		if err != nil {
+			return Chromosome{}, fmt.Errorf("exact optimum: %w", err)
+		}
+		genes = solution.Genes
+	} else {
+		genes = MaxBMatching(graph)
+	}
+	optimum := Chromosome{Genes: genes}
+	Evaluate(&optimum, graph)
+	return optimum, nil
+}
+
+// randomMatching строит допустимое решение из генома случайной плотности, чтобы выборка
+// покрывала и почти пустые, и почти максимальные паросочетания
+func randomMatching(graph *Graph) Chromosome {
+	density := rng.Float64()
+	chrom := Chromosome{Genes: make([]bool, len(graph.Edges))}
+	for i := range chrom.Genes {
+		chrom.Genes[i] = rng.Float64() < density
+	}
+	RepairFast(&chrom, graph)
+	Evaluate(&chrom, graph)
+	return chrom
+}
+
+func fitnessDistanceCorrelation(samples []Chromosome, optimum Chromosome) FDCResult {
+	result := FDCResult{Points: make([]FDCPoint, len(samples))}
+	distances := make([]float64, len(samples))
+	fitness := make([]float64, len(samples))
+	for i, s := range samples {
+		d := hammingDistance(s, optimum)
+		result.Points[i] = FDCPoint{Distance: d, Fitness: s.Fitness}
+		distances[i] = float64(d)
+		fitness[i] = float64(s.Fitness)
+	}
+	result.Correlation = pearson(distances, fitness)
+	return result
+}
+
+// pearson возвращает коэффициент корреляции; при нулевой дисперсии — 0
+func pearson(x, y []float64) float64 {
+	if len(x) == 0 {
+		return 0
+	}
+	var mx, my float64
+	for i := range x {
+		mx += x[i]
+		my += y[i]
+	}
+	mx /= float64(len(x))
+	my /= float64(len(y))
+	var cov, vx, vy float64
+	for i := range x {
+		cov += (x[i] - mx) * (y[i] - my)
+		vx += (x[i] - mx) * (x[i] - mx)
+		vy += (y[i] - my) * (y[i] - my)
+	}
+	if vx == 0 || vy == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(vx*vy)
+}
+
+// sampleNeutrality проверяет у каждого решения до neutralityNeighbours случайных соседей
+func sampleNeutrality(samples []Chromosome, graph *Graph) NeutralityResult {
+	var result NeutralityResult
+	neutral := 0
+	for _, s := range samples {
+		genes := rng.Perm(len(graph.Edges))
+		if len(genes) > neutralityNeighbours {
+			genes = genes[:neutralityNeighbours]
+		}
+		for _, g := range genes {
+			neighbour := copyChromosome(s)
+			neighbour.Genes[g] = !neighbour.Genes[g]
+			RepairFast(&neighbour, graph)
+			Evaluate(&neighbour, graph)
+			result.Neighbours++
+			if neighbour.Fitness == s.Fitness {
+				neutral++
+			}
+		}
+	}
+	if result.Neighbours > 0 {
+		result.Ratio = float64(neutral) / float64(result.Neighbours)
+	}
+	return result
+}
+
+// randomWalks проходит cfg.Walks блужданий под оператором strategy. Операторам с вероятностью
+// на ген передаётся 1/L (в среднем один переворот за шаг), операторам, применяемым к особи
+// целиком, — 1 (оператор применяется на каждом шаге)
+func randomWalks(graph *Graph, strategy MutationStrategy, cfg LandscapeConfig) WalkAutocorrelation {
+	rate := 1.0
+	if MutationRateScope(strategy) == RatePerGene {
+		rate = 1 / float64(len(graph.Edges))
+	}
+	result := WalkAutocorrelation{
+		Strategy:        strategy.GetName(),
+		Rate:            rate,
+		Autocorrelation: make([]float64, cfg.MaxLag+1),
+	}
+
+	counted, changed, neutral, stalled := 0, 0, 0, 0
+	for w := 0; w < cfg.Walks; w++ {
+		chrom := randomMatching(graph)
+		series := make([]float64, cfg.WalkLength+1)
+		series[0] = float64(chrom.Fitness)
+		for t := 1; t <= cfg.WalkLength; t++ {
+			prev := copyChromosome(chrom)
+			strategy.Mutate(&chrom, rate, graph)
+			RepairFast(&chrom, graph)
+			Evaluate(&chrom, graph)
+			series[t] = float64(chrom.Fitness)
+			if hammingDistance(prev, chrom) == 0 {
+				stalled++
+				continue
+			}
+			changed++
+			if chrom.Fitness == prev.Fitness {
+				neutral++
+			}
+		}
+		// Блуждание с постоянным фитнесом не несёт сведений о корреляции
+		if r, ok := autocorrelation(series, cfg.MaxLag); ok {
+			for k, v := range r {
+				result.Autocorrelation[k] += v
+			}
+			counted++
+		}
+	}
+
+	steps := cfg.Walks * cfg.WalkLength
+	if counted > 0 {
+		for k := range result.Autocorrelation {
+			result.Autocorrelation[k] /= float64(counted)
+		}
+	} else {
+		// Все блуждания стояли на плато: ландшафт для оператора плоский
+		for k := range result.Autocorrelation {
+			result.Autocorrelation[k] = 1
+		}
+	}
+	result.CorrelationLength = correlationLength(result.Autocorrelation, cfg.WalkLength)
+	if changed > 0 {
+		result.Neutrality = float64(neutral) / float64(changed)
+	}
+	if steps > 0 {
+		result.Stalled = float64(stalled) / float64(steps)
+	}
+	return result
+}
+
+// autocorrelation возвращает r(k) ряда для k = 0..maxLag и false при нулевой дисперсии
+func autocorrelation(series []float64, maxLag int) ([]float64, bool) {
+	mean := 0.0
+	for _, v := range series {
+		mean += v
+	}
+	mean /= float64(len(series))
+	variance := 0.0
+	for _, v := range series {
+		variance += (v - mean) * (v - mean)
+	}
+	if variance == 0 {
+		return nil, false
+	}
+	r := make([]float64, maxLag+1)
+	for k := range r {
+		sum := 0.0
+		for t := 0; t+k < len(series); t++ {
+			sum += (series[t] - mean) * (series[t+k] - mean)
+		}
+		r[k] = sum / variance
+	}
+	return r, true
+}
+
+// correlationLength возвращает −1/ln|r(1)|; для r(1) ≤ 0 — 0, для r(1) ≥ 1 — длину блуждания
+func correlationLength(r []float64, walkLength int) float64 {
+	if len(r) < 2 || r[1] <= 0 {
+		return 0
+	}
+	if r[1] >= 1 {
+		return float64(walkLength)
+	}
+	return math.Min(-1/math.Log(r[1]), float64(walkLength))
+}
+
+// enumerateLocalOptima перебирает все допустимые решения графа поиском с возвратом
+func enumerateLocalOptima(graph *Graph) LocalOptimaResult {
+	result := LocalOptimaResult{Computed: true}
+	levels := make(map[int]int)
+	best := math.MinInt
+	load := newVertexLoad(graph)
+	chrom := Chromosome{Genes: make([]bool, len(graph.Edges))}
+
+	var visit func(i int)
+	visit = func(i int) {
+		if i < len(graph.Edges) {
+			visit(i + 1)
+			if load.fits(graph.Edges[i]) {
+				load.add(graph.Edges[i])
+				chrom.Genes[i] = true
+				visit(i + 1)
+				chrom.Genes[i] = false
+				load.remove(graph.Edges[i])
+			}
+			return
+		}
+		result.Solutions++
+		Evaluate(&chrom, graph)
+		if !isLocalOptimum(chrom, load, graph) {
+			return
+		}
+		result.LocalOptima++
+		levels[chrom.Fitness]++
+		best = max(best, chrom.Fitness)
+	}
+	visit(0)
+
+	result.GlobalOptima = levels[best]
+	for fitness, count := range levels {
+		result.Levels = append(result.Levels, OptimaLevel{Fitness: fitness, Count: count})
+	}
+	sort.Slice(result.Levels, func(i, j int) bool {
+		return result.Levels[i].Fitness > result.Levels[j].Fitness
+	})
+	return result
+}
+
+// isLocalOptimum проверяет, что ни добавление, ни удаление одного ребра не увеличивает фитнес
+func isLocalOptimum(chrom Chromosome, load *vertexLoad, graph *Graph) bool {
+	for i, on := range chrom.Genes {
+		if !on && !load.fits(graph.Edges[i]) {
+			continue
+		}
+		neighbour := copyChromosome(chrom)
+		neighbour.Genes[i] = !on
+		Evaluate(&neighbour, graph)
+		if neighbour.Fitness > chrom.Fitness {
+			return false
+		}
+	}
+	return true
+}
+
+// ------------------------ Экспорт ------------------------ //
+
+// SaveLandscape сохраняет отчёт анализа ландшафта в JSON-файл
+func SaveLandscape(path string, report LandscapeReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
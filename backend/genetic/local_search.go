@@ -0,0 +1,536 @@
+package genetic
+
+import (
+	"fmt"
+)
+
+// LocalSearch улучшает допустимое паросочетание.
+// Improve чинит хромосому, улучшает её и пересчитывает приспособленность.
+type LocalSearch interface {
+	Improve(chrom *Chromosome, graph *Graph)
+	GetName() string
+}
+
+// LearningMode определяет, как результат локального поиска влияет на потомка
+type LearningMode int
+
+const (
+	// Lamarckian — улучшенный геном записывается в потомка
+	Lamarckian LearningMode = iota
+	// Baldwinian — потомок сохраняет свой геном, но получает приспособленность улучшенного решения
+	Baldwinian
+)
+
+func (l LearningMode) String() string {
+	switch l {
+	case Lamarckian:
+		return "Lamarckian"
+	case Baldwinian:
+		return "Baldwinian"
+	default:
+		return "Unknown"
+	}
+}
+
+// LocalSearchConfig содержит настройки локального поиска меметической модели
+type LocalSearchConfig struct {
+	Search   LocalSearch  // Алгоритм локального поиска (nil — по умолчанию для задачи и графа, см. localSearch)
+	Learning LearningMode // Ламарковское или болдуиновское обучение
+	Fraction float64      // Доля улучшаемых потомков (0 — все)
+}
+
+// check проверяет, что заданный поиск применим к задаче и графу. Поиски по увеличивающим
+// цепям работают только с паросочетаниями, и все они, кроме CapacitatedAugmentingSearch,
+// допускают одного партнёра на вершину
+func (c LocalSearchConfig) check(problem ProblemType, graph *Graph) error {
+	if c.Search == nil {
+		return nil
+	}
+	if problem != Matching {
+		return fmt.Errorf("local search %s improves matchings, not solutions of %v; leave it unset to use bit-flip hill climbing",
+			c.Search.GetName(), problem)
+	}
+	if _, ok := c.Search.(*CapacitatedAugmentingSearch); graph.Capacities != nil && !ok {
+		return fmt.Errorf("local search %s allows one partner per vertex; graphs with vertex capacities need %s",
+			c.Search.GetName(), (&CapacitatedAugmentingSearch{}).GetName())
+	}
+	return nil
+}
+
+// usesLocalSearch сообщает, применяет ли модель локальный поиск ModelConfig.LocalSearch
+func (m EvolutionModelConfig) usesLocalSearch() bool {
+	switch m.Model {
+	case Memetic:
+		return true
+	case Combined:
+		return m.UseLocalSearch
+	case AntColony:
+		return m.ACO.LocalSearch
+	default:
+		return false
+	}
+}
+
+// localSearch возвращает поиск, который выполняет applyLocalSearch: заданный в настройках или,
+// если он не задан, одну увеличивающую цепь (с учётом ёмкостей вершин) для паросочетания
+// и один проход переворотов генов для других задач
+func (ga *Algorithm) localSearch() LocalSearch {
+	if search := ga.ModelConfig.LocalSearch.Search; search != nil {
+		return search
+	}
+	if ga.Problem.Type() != Matching {
+		return problemHillClimb{problem: ga.Problem}
+	}
+	if ga.Graph.Capacities != nil {
+		return &CapacitatedAugmentingSearch{MaxAugmentations: 1}
+	}
+	return &AugmentingPathSearch{MaxAugmentations: 1}
+}
+
+// applyLocalSearch улучшает починенного и оценённого потомка согласно ModelConfig.LocalSearch
+func (ga *Algorithm) applyLocalSearch(child *Chromosome) {
+	cfg := ga.ModelConfig.LocalSearch
+	if cfg.Fraction > 0 && rng.Float64() >= cfg.Fraction {
+		return
+	}
+	search := ga.localSearch()
+
+	if cfg.Learning == Baldwinian {
+		improved := copyChromosome(*child)
+		search.Improve(&improved, ga.Graph)
+		child.Fitness = improved.Fitness
+		return
+	}
+	search.Improve(child, ga.Graph)
+}
+
+// ----------------------- Представление паросочетания ----------------------- //
+
+// incidence — ребро edge, ведущее в вершину to
+type incidence struct {
+	to   int
+	edge int
+}
+
+// matchingState хранит паросочетание в виде массива партнёров для быстрых локальных ходов
+type matchingState struct {
+	adj      [][]incidence
+	mate     []int // Партнёр вершины или -1
+	mateEdge []int // Индекс ребра паросочетания для вершины или -1
+}
+
+// newMatchingState строит состояние по допустимой хромосоме
+func newMatchingState(chrom *Chromosome, graph *Graph) *matchingState {
+	n := graph.NumVertices
+	s := &matchingState{
+		adj:      make([][]incidence, n),
+		mate:     make([]int, n),
+		mateEdge: make([]int, n),
+	}
+	for v := 0; v < n; v++ {
+		s.mate[v] = -1
+		s.mateEdge[v] = -1
+	}
+	for i, e := range graph.Edges {
+		if e.U == e.V {
+			continue
+		}
+		s.adj[e.U] = append(s.adj[e.U], incidence{to: e.V, edge: i})
+		s.adj[e.V] = append(s.adj[e.V], incidence{to: e.U, edge: i})
+		if chrom.Genes[i] {
+			s.match(e.U, e.V, i)
+		}
+	}
+	return s
+}
+
+// match объединяет u и v ребром edge, прежние партнёры не освобождаются
+func (s *matchingState) match(u, v, edge int) {
+	s.mate[u], s.mate[v] = v, u
+	s.mateEdge[u], s.mateEdge[v] = edge, edge
+}
+
+// unmatch освобождает вершину v и её партнёра
+func (s *matchingState) unmatch(v int) {
+	if u := s.mate[v]; u >= 0 {
+		s.mate[u], s.mateEdge[u] = -1, -1
+	}
+	s.mate[v], s.mateEdge[v] = -1, -1
+}
+
+// edgeBetween возвращает индекс ребра между u и v или -1
+func (s *matchingState) edgeBetween(u, v int) int {
+	for _, inc := range s.adj[u] {
+		if inc.to == v {
+			return inc.edge
+		}
+	}
+	return -1
+}
+
+// flipPath увеличивает паросочетание вдоль пути root, w1, x1, ..., wk,
+// где рёбра (x_i, w_{i+1}) не входят в паросочетание, а wk свободна
+func (s *matchingState) flipPath(path []int) {
+	for i := 0; i+1 < len(path); i += 2 {
+		s.match(path[i], path[i+1], s.edgeBetween(path[i], path[i+1]))
+	}
+}
+
+// writeBack записывает паросочетание в гены хромосомы
+func (s *matchingState) writeBack(chrom *Chromosome) {
+	for i := range chrom.Genes {
+		chrom.Genes[i] = false
+	}
+	for v, e := range s.mateEdge {
+		if e >= 0 && s.mate[v] > v {
+			chrom.Genes[e] = true
+		}
+	}
+}
+
+// withMatching чинит хромосому, применяет improve к её паросочетанию и пересчитывает фитнес.
+// matchingState допускает одного партнёра на вершину, поэтому для графа с ёмкостями
+// вместо improve выполняется CapacitatedAugmentingSearch. Алгоритм не допускает такой замены:
+// New отклоняет эти поиски для графа с ёмкостями (см. LocalSearchConfig.check).
+func withMatching(chrom *Chromosome, graph *Graph, improve func(s *matchingState)) {
+	if graph.Capacities != nil {
+		(&CapacitatedAugmentingSearch{}).Improve(chrom, graph)
+		return
+	}
+	RepairFast(chrom, graph)
+	s := newMatchingState(chrom, graph)
+	improve(s)
+	s.writeBack(chrom)
+	Evaluate(chrom, graph)
+}
+
+// ------------------- Повторная аугментация ------------------- //
+
+// AugmentingPathSearch ищет увеличивающие цепи поиском в ширину без обработки нечётных циклов
+// и повторяет аугментацию, пока цепи находятся
+type AugmentingPathSearch struct {
+	MaxAugmentations int // Предел числа аугментаций (0 — до исчерпания)
+}
+
+func (l *AugmentingPathSearch) Improve(chrom *Chromosome, graph *Graph) {
+	withMatching(chrom, graph, func(s *matchingState) {
+		repeatAugment(s, l.MaxAugmentations, func(root int) []int {
+			return s.simpleAugmentingPath(root)
+		})
+	})
+}
+
+func (l *AugmentingPathSearch) GetName() string {
+	return "AugmentingPath"
+}
+
+// repeatAugment проходит по свободным вершинам, пока find находит увеличивающие пути
+func repeatAugment(s *matchingState, limit int, find func(root int) []int) {
+	done := 0
+	for improved := true; improved; {
+		improved = false
+		for root := range s.mate {
+			if s.mate[root] >= 0 {
+				continue
+			}
+			if path := find(root); path != nil {
+				s.flipPath(path)
+				improved = true
+				done++
+				if limit > 0 && done >= limit {
+					return
+				}
+			}
+		}
+	}
+}
+
+// simpleAugmentingPath ищет чередующийся путь от root до свободной вершины.
+// Каждая вершина посещается один раз, поэтому часть путей через нечётные циклы теряется.
+func (s *matchingState) simpleAugmentingPath(root int) []int {
+	n := len(s.mate)
+	parent := make([]int, n) // Для нечётной вершины — чётная вершина, из которой пришли
+	for i := range parent {
+		parent[i] = -1
+	}
+	visited := make([]bool, n)
+	visited[root] = true
+	queue := []int{root}
+
+	for qi := 0; qi < len(queue); qi++ {
+		u := queue[qi]
+		for _, inc := range s.adj[u] {
+			w := inc.to
+			if visited[w] || s.mate[u] == w {
+				continue
+			}
+			visited[w] = true
+			parent[w] = u
+			if s.mate[w] < 0 {
+				// Восстанавливаем путь root ... u, w
+				var path []int
+				for v := w; v >= 0; {
+					path = append(path, v, parent[v])
+					if parent[v] == root {
+						break
+					}
+					v = s.mate[parent[v]]
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if x := s.mate[w]; !visited[x] {
+				visited[x] = true
+				queue = append(queue, x)
+			}
+		}
+	}
+	return nil
+}
+
+// ------------------------- 2-opt обмены ------------------------- //
+
+// TwoOptSearch заполняет свободные рёбра и заменяет ребро паросочетания (u, v)
+// двумя рёбрами (u, a) и (v, b) со свободными a и b, пока это возможно
+type TwoOptSearch struct {
+	MaxPasses int // Предел числа проходов (0 — до исчерпания)
+}
+
+func (l *TwoOptSearch) Improve(chrom *Chromosome, graph *Graph) {
+	withMatching(chrom, graph, func(s *matchingState) {
+		for pass := 0; l.MaxPasses <= 0 || pass < l.MaxPasses; pass++ {
+			improved := false
+			// Рёбра с двумя свободными концами добавляются сразу
+			for u := range s.adj {
+				for _, inc := range s.adj[u] {
+					if s.mate[u] < 0 && s.mate[inc.to] < 0 {
+						s.match(u, inc.to, inc.edge)
+						improved = true
+					}
+				}
+			}
+			for u := range s.mate {
+				v := s.mate[u]
+				if v < u {
+					continue
+				}
+				a := s.freeNeighbor(u, -1)
+				if a < 0 {
+					continue
+				}
+				b := s.freeNeighbor(v, a)
+				if b < 0 {
+					continue
+				}
+				s.unmatch(u)
+				s.match(u, a, s.edgeBetween(u, a))
+				s.match(v, b, s.edgeBetween(v, b))
+				improved = true
+			}
+			if !improved {
+				return
+			}
+		}
+	})
+}
+
+// freeNeighbor возвращает свободного соседа v, отличного от except, или -1
+func (s *matchingState) freeNeighbor(v, except int) int {
+	for _, inc := range s.adj[v] {
+		if inc.to != except && s.mate[inc.to] < 0 {
+			return inc.to
+		}
+	}
+	return -1
+}
+
+func (l *TwoOptSearch) GetName() string {
+	return "TwoOpt"
+}
+
+// ------------------- Аугментация с цветками ------------------- //
+
+// BlossomSearch ищет увеличивающие цепи алгоритмом Эдмондса со сжатием цветков,
+// начиная с паросочетания хромосомы. Без ограничения даёт наибольшее паросочетание.
+type BlossomSearch struct {
+	MaxAugmentations int // Предел числа аугментаций (0 — до исчерпания)
+}
+
+func (l *BlossomSearch) Improve(chrom *Chromosome, graph *Graph) {
+	withMatching(chrom, graph, func(s *matchingState) {
+		b := newBlossomFinder(s)
+		repeatAugment(s, l.MaxAugmentations, b.findPath)
+	})
+}
+
+func (l *BlossomSearch) GetName() string {
+	return "Blossom"
+}
+
+// blossomFinder хранит рабочие массивы поиска Эдмондса
+type blossomFinder struct {
+	s       *matchingState
+	parent  []int
+	base    []int
+	used    []bool
+	blossom []bool
+	queue   []int
+}
+
+func newBlossomFinder(s *matchingState) *blossomFinder {
+	n := len(s.mate)
+	return &blossomFinder{
+		s:       s,
+		parent:  make([]int, n),
+		base:    make([]int, n),
+		used:    make([]bool, n),
+		blossom: make([]bool, n),
+	}
+}
+
+// findPath возвращает увеличивающий путь от root в формате flipPath или nil
+func (b *blossomFinder) findPath(root int) []int {
+	mate := b.s.mate
+	for i := range mate {
+		b.parent[i] = -1
+		b.base[i] = i
+		b.used[i] = false
+	}
+	b.used[root] = true
+	b.queue = append(b.queue[:0], root)
+
+	for qi := 0; qi < len(b.queue); qi++ {
+		v := b.queue[qi]
+		for _, inc := range b.s.adj[v] {
+			to := inc.to
+			if b.base[v] == b.base[to] || mate[v] == to {
+				continue
+			}
+			if to == root || (mate[to] >= 0 && b.parent[mate[to]] >= 0) {
+				// Найден нечётный цикл: сжимаем цветок
+				cur := b.lca(v, to)
+				for i := range b.blossom {
+					b.blossom[i] = false
+				}
+				b.markPath(v, cur, to)
+				b.markPath(to, cur, v)
+				for i := range mate {
+					if b.blossom[b.base[i]] {
+						b.base[i] = cur
+						if !b.used[i] {
+							b.used[i] = true
+							b.queue = append(b.queue, i)
+						}
+					}
+				}
+			} else if b.parent[to] < 0 {
+				b.parent[to] = v
+				if mate[to] < 0 {
+					return b.path(to)
+				}
+				b.used[mate[to]] = true
+				b.queue = append(b.queue, mate[to])
+			}
+		}
+	}
+	return nil
+}
+
+// lca находит базу цветка, содержащего a и b
+func (b *blossomFinder) lca(x, y int) int {
+	mate := b.s.mate
+	seen := make([]bool, len(mate))
+	for {
+		x = b.base[x]
+		seen[x] = true
+		if mate[x] < 0 {
+			break
+		}
+		x = b.parent[mate[x]]
+	}
+	for {
+		y = b.base[y]
+		if seen[y] {
+			return y
+		}
+		y = b.parent[mate[y]]
+	}
+}
+
+func (b *blossomFinder) markPath(v, base, child int) {
+	mate := b.s.mate
+	for b.base[v] != base {
+		b.blossom[b.base[v]] = true
+		b.blossom[b.base[mate[v]]] = true
+		b.parent[v] = child
+		child = mate[v]
+		v = b.parent[mate[v]]
+	}
+}
+
+// path разворачивает дерево поиска от свободной вершины end в пары новых рёбер
+func (b *blossomFinder) path(end int) []int {
+	var path []int
+	for v := end; v >= 0; {
+		pv := b.parent[v]
+		next := b.s.mate[pv]
+		path = append(path, v, pv)
+		v = next
+	}
+	return path
+}
+
+// ---------------- Чередующиеся пути ограниченной длины ---------------- //
+
+// BoundedDepthSearch перебирает в глубину простые чередующиеся пути длиной не более MaxDepth рёбер
+// и применяет первый найденный увеличивающий путь
+type BoundedDepthSearch struct {
+	MaxDepth int // Максимальная длина пути в рёбрах (по умолчанию 5)
+}
+
+func (l *BoundedDepthSearch) Improve(chrom *Chromosome, graph *Graph) {
+	maxDepth := l.MaxDepth
+	if maxDepth < 1 {
+		maxDepth = 5
+	}
+	withMatching(chrom, graph, func(s *matchingState) {
+		onPath := make([]bool, len(s.mate))
+		repeatAugment(s, 0, func(root int) []int {
+			onPath[root] = true
+			path := s.boundedPath([]int{root}, maxDepth, onPath)
+			onPath[root] = false
+			return path
+		})
+	})
+}
+
+// boundedPath продолжает чередующийся путь prefix, заканчивающийся чётной вершиной
+func (s *matchingState) boundedPath(prefix []int, depth int, onPath []bool) []int {
+	u := prefix[len(prefix)-1]
+	for _, inc := range s.adj[u] {
+		w := inc.to
+		if onPath[w] || s.mate[u] == w {
+			continue
+		}
+		if s.mate[w] < 0 {
+			return append(append([]int{}, prefix...), w)
+		}
+		x := s.mate[w]
+		if depth < 3 || onPath[x] {
+			continue
+		}
+		onPath[w], onPath[x] = true, true
+		path := s.boundedPath(append(prefix, w, x), depth-2, onPath)
+		onPath[w], onPath[x] = false, false
+		if path != nil {
+			return path
+		}
+	}
+	return nil
+}
+
+func (l *BoundedDepthSearch) GetName() string {
+	return "BoundedDepth"
+}
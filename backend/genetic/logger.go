@@ -77,41 +77,48 @@ func (l *Logger) LogAlgorithmStart(ga *Algorithm) {
 	l.log(MILESTONE, "Запуск алгоритма: модель=%s, селекция=%s, кроссовер=%s, мутация=%s, размер популяции=%d, поколений=%d",
 		ga.EvolutionModel.GetModelName(),
 		ga.SelectionStrategy.GetName(),
-		ga.CrossoverStrategy.GetName(),
-		ga.MutationStrategy.GetName(),
+		operatorName(ga.CrossoverStrategy),
+		operatorName(ga.MutationStrategy),
 		ga.PopulationSize, ga.Generations)
+	if ga.ModelConfig.usesLocalSearch() {
+		l.log(INFO, "Локальный поиск: %s (%v)", ga.localSearch().GetName(), ga.ModelConfig.LocalSearch.Learning)
+	}
+}
+
+// operatorName возвращает имя оператора или «—», если модель работает без него
+func operatorName(op interface{ GetName() string }) string {
+	if op == nil {
+		return "—"
+	}
+	return op.GetName()
 }
 
 // countValidMatchingEdges возвращает количество рёбер в допустимом паросочетании для данной хромосомы
 func countValidMatchingEdges(chrom Chromosome, graph *Graph) int {
-	used := make(map[int]bool)
-	count := 0
-	for i, gene := range chrom.Genes {
-		if gene {
-			edge := graph.Edges[i]
-			if !used[edge.U] && !used[edge.V] {
-				used[edge.U] = true
-				used[edge.V] = true
-				count++
-			}
-		}
-	}
-	return count
+	return len(ValidMatchingEdges(chrom.Genes, graph))
 }
 
 // LogGeneration логирует информацию о текущем поколении
 func (l *Logger) LogGeneration(ga *Algorithm) {
 	avgFitness := 0.0
 	for _, chrom := range ga.Population {
-		avgFitness += float64(countValidMatchingEdges(chrom, ga.Graph))
+		avgFitness += float64(ga.Problem.Objective(chrom.Genes))
 	}
 	avgFitness /= float64(len(ga.Population))
 
-	l.log(INFO, "Поколение %d: лучший фитнес=%d (рёбер=%d), средний фитнес=%.1f, глобально лучший фитнес=%d (рёбер=%d)",
+	diversity := ComputeDiversity(ga.Population)
+
+	l.log(INFO, "Поколение %d: лучший фитнес=%d (рёбер=%d), средний фитнес=%.1f, глобально лучший фитнес=%d (рёбер=%d), уникальных=%d, Хэмминг=%.1f",
 		ga.CurrentGeneration,
 		ga.localBest.Fitness, ga.LocalBestEdges,
 		avgFitness,
-		ga.bestSoFar.Fitness, ga.BestSoFarEdges)
+		ga.bestSoFar.Fitness, ga.BestSoFarEdges,
+		diversity.UniqueGenomes, diversity.MeanHamming)
+
+	if stats, ok := ga.CacheStats(); ok {
+		l.log(INFO, "Кэш фитнеса: попаданий=%d, промахов=%d (%.1f%%), записей=%d, вытеснено=%d",
+			stats.Hits, stats.Misses, 100*stats.HitRate(), stats.Size, stats.Evictions)
+	}
 }
 
 // LogStrategyChange логирует изменение стратегии
@@ -145,6 +152,38 @@ func (l *Logger) LogCompletion(ga *Algorithm) {
 	}
 }
 
+// LogOperatorStats логирует статистику кредитования под-операторов
+func (l *Logger) LogOperatorStats(kind string, stats []OperatorStats) {
+	for _, st := range stats {
+		l.log(INFO, "Оператор %s %s: вероятность=%.3f, качество=%.3f, применений=%d, улучшений=%d, награда=%.1f",
+			kind, st.Name, st.Probability, st.Quality, st.Uses, st.Successes, st.TotalReward)
+	}
+}
+
+// LogIslandStats логирует статистику по островам
+func (l *Logger) LogIslandStats(stats []IslandStats) {
+	for _, st := range stats {
+		l.log(INFO, "Остров %d: размер=%d, лучший=%d, средний=%.1f, уникальных=%d, селекция=%s, кроссовер=%s, мутация=%s (%.3f)",
+			st.Island, st.Size, st.BestFitness, st.AverageFitness, st.UniqueGenomes,
+			st.Selection, st.Crossover, st.Mutation, st.MutationRate)
+	}
+}
+
+// LogRates логирует вероятности мутации и кроссовера после поколения
+func (l *Logger) LogRates(p RatePoint) {
+	l.log(INFO, "Вероятности: мутация=%.4f, кроссовер=%.3f, успешных потомков=%.1f%%",
+		p.MutationRate, p.CrossoverRate, 100*p.SuccessRate)
+}
+
+// LogRestart логирует срабатывание реакции на стагнацию
+func (l *Logger) LogRestart(e RestartEvent, mutationRate float64) {
+	l.log(INFO, "Стагнация %d поколений (лучший фитнес=%d, рёбер=%d): %v, заменено особей=%d, вероятность мутации=%.4f",
+		e.Stagnated, e.BestFitness, e.BestEdges, e.Response, e.Replaced, mutationRate)
+	if e.Response == RestartFull {
+		l.log(INFO, "Генератор случайных чисел пересеян: seed=%d", e.Seed)
+	}
+}
+
 // LogMilestone logs a milestone message
 func (l *Logger) LogMilestone(format string, args ...interface{}) {
 	l.log(MILESTONE, format, args...)
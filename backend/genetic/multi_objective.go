@@ -0,0 +1,442 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Многокритериальная оптимизация паросочетаний (NSGA-II). Приспособленность Chromosome.Fitness
+// по-прежнему скалярная и используется для лучшего решения за всё время, а отбор ведётся
+// по вектору целевых функций: недоминируемая сортировка и расстояние скученности.
+
+// Objective — целевая функция многокритериальной задачи; значения максимизируются
+type Objective interface {
+	Value(genes []bool, graph *Graph) float64
+	// Worst возвращает значение, не превосходящее Value ни для какого решения (точка отсчёта гиперобъёма)
+	Worst(graph *Graph) float64
+	GetName() string
+}
+
+// CardinalityObjective — число рёбер допустимой части паросочетания
+type CardinalityObjective struct{}
+
+func (o *CardinalityObjective) Value(genes []bool, graph *Graph) float64 {
+	return float64(len(ValidMatchingEdges(genes, graph)))
+}
+
+func (o *CardinalityObjective) Worst(graph *Graph) float64 { return 0 }
+func (o *CardinalityObjective) GetName() string            { return "Cardinality" }
+
+// CostObjective — суммарная стоимость допустимой части паросочетания со знаком минус
+// (стоимость минимизируется)
+type CostObjective struct{}
+
+func (o *CostObjective) Value(genes []bool, graph *Graph) float64 {
+	_, cost := MatchingCost(genes, graph)
+	return -cost
+}
+
+func (o *CostObjective) Worst(graph *Graph) float64 {
+	total := 0.0
+	for _, c := range graph.Costs {
+		total += c
+	}
+	return -total
+}
+
+func (o *CostObjective) GetName() string { return "-Cost" }
+
+// PreferredCoverageObjective — число предпочтительных вершин, покрытых паросочетанием
+type PreferredCoverageObjective struct {
+	Vertices []int
+}
+
+func (o *PreferredCoverageObjective) Value(genes []bool, graph *Graph) float64 {
+	covered := make([]bool, graph.NumVertices)
+	for _, i := range ValidMatchingEdges(genes, graph) {
+		covered[graph.Edges[i].U] = true
+		covered[graph.Edges[i].V] = true
+	}
+	count := 0
+	for _, v := range o.Vertices {
+		if covered[v] {
+			count++
+		}
+	}
+	return float64(count)
+}
+
+func (o *PreferredCoverageObjective) Worst(graph *Graph) float64 { return 0 }
+func (o *PreferredCoverageObjective) GetName() string            { return "Preferred" }
+
+// MultiObjectiveConfig содержит настройки модели NSGA-II
+type MultiObjectiveConfig struct {
+	// Objectives — целевые функции (пусто — мощность, а также стоимость для взвешенного
+	// графа и покрытие Preferred, если вершины заданы)
+	Objectives []Objective
+	Preferred  []int // Предпочтительные вершины для цели по умолчанию
+	// ReferencePoint — точка отсчёта гиперобъёма (nil — Worst каждой цели)
+	ReferencePoint []float64
+}
+
+// objectives возвращает целевые функции с учётом значений по умолчанию
+func (c MultiObjectiveConfig) objectives(graph *Graph) []Objective {
+	if len(c.Objectives) > 0 {
+		return c.Objectives
+	}
+	objectives := []Objective{&CardinalityObjective{}}
+	if graph.Costs != nil {
+		objectives = append(objectives, &CostObjective{})
+	}
+	if len(c.Preferred) > 0 {
+		objectives = append(objectives, &PreferredCoverageObjective{Vertices: c.Preferred})
+	}
+	return objectives
+}
+
+// referencePoint возвращает точку отсчёта гиперобъёма
+func (c MultiObjectiveConfig) referencePoint(graph *Graph, objectives []Objective) []float64 {
+	if c.ReferencePoint != nil {
+		return c.ReferencePoint
+	}
+	ref := make([]float64, len(objectives))
+	for i, o := range objectives {
+		ref[i] = o.Worst(graph)
+	}
+	return ref
+}
+
+// Validate проверяет настройки для графа
+func (c MultiObjectiveConfig) Validate(graph *Graph) error {
+	for _, v := range c.Preferred {
+		if v < 0 || v >= graph.NumVertices {
+			return fmt.Errorf("preferred vertex %d is out of range for %d vertices", v, graph.NumVertices)
+		}
+	}
+	objectives := c.objectives(graph)
+	if len(objectives) < 2 {
+		return errors.New("multi-objective model needs at least two objectives: use a weighted graph, preferred vertices or explicit objectives")
+	}
+	if c.ReferencePoint != nil && len(c.ReferencePoint) != len(objectives) {
+		return fmt.Errorf("reference point has %d coordinates for %d objectives", len(c.ReferencePoint), len(objectives))
+	}
+	return nil
+}
+
+// ParetoPoint — недоминируемое решение и значения его целевых функций
+type ParetoPoint struct {
+	Genes      []bool
+	Objectives []float64
+}
+
+// ------------------------ Доминирование ------------------------ //
+
+// Dominates сообщает, доминирует ли вектор a вектор b (не хуже по всем целям и лучше хотя бы по одной)
+func Dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		}
+		if a[i] > b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// NonDominatedSort разбивает векторы на фронты (быстрая недоминируемая сортировка Деба);
+// фронт 0 — недоминируемые векторы
+func NonDominatedSort(values [][]float64) [][]int {
+	n := len(values)
+	dominated := make([][]int, n) // Кого доминирует i
+	count := make([]int, n)       // Сколькими векторами доминируется i
+	var fronts [][]int
+	var current []int
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if Dominates(values[i], values[j]) {
+				dominated[i] = append(dominated[i], j)
+				count[j]++
+			} else if Dominates(values[j], values[i]) {
+				dominated[j] = append(dominated[j], i)
+				count[i]++
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if count[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	for len(current) > 0 {
+		fronts = append(fronts, current)
+		var next []int
+		for _, i := range current {
+			for _, j := range dominated[i] {
+				count[j]--
+				if count[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		current = next
+	}
+	return fronts
+}
+
+// CrowdingDistance возвращает расстояние скученности векторов фронта front
+// (в порядке front); крайние точки по каждой цели получают +Inf
+func CrowdingDistance(values [][]float64, front []int) []float64 {
+	dist := make([]float64, len(front))
+	if len(front) == 0 {
+		return dist
+	}
+	order := make([]int, len(front))
+	for k := range values[front[0]] {
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool {
+			return values[front[order[a]]][k] < values[front[order[b]]][k]
+		})
+		lo := values[front[order[0]]][k]
+		hi := values[front[order[len(order)-1]]][k]
+		dist[order[0]] = math.Inf(1)
+		dist[order[len(order)-1]] = math.Inf(1)
+		if hi == lo {
+			continue
+		}
+		for i := 1; i < len(order)-1; i++ {
+			dist[order[i]] += (values[front[order[i+1]]][k] - values[front[order[i-1]]][k]) / (hi - lo)
+		}
+	}
+	return dist
+}
+
+// Hypervolume возвращает объём области, доминируемой точками и ограниченной снизу точкой ref
+// (рекурсивное разбиение по последней цели; точки, не превосходящие ref, вклада не дают)
+func Hypervolume(points [][]float64, ref []float64) float64 {
+	var inside [][]float64
+	for _, p := range points {
+		ok := true
+		for i := range ref {
+			if p[i] <= ref[i] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			inside = append(inside, p)
+		}
+	}
+	return hypervolume(inside, ref, len(ref))
+}
+
+// hypervolume считает объём по первым d координатам точек, строго превосходящих ref
+func hypervolume(points [][]float64, ref []float64, d int) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	if d == 1 {
+		best := points[0][0]
+		for _, p := range points {
+			best = math.Max(best, p[0])
+		}
+		return best - ref[0]
+	}
+	sorted := make([][]float64, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a][d-1] > sorted[b][d-1] })
+
+	// Слой между соседними значениями последней цели покрывают все точки, лежащие выше него
+	volume := 0.0
+	for i := range sorted {
+		lower := ref[d-1]
+		if i+1 < len(sorted) {
+			lower = sorted[i+1][d-1]
+		}
+		if height := sorted[i][d-1] - lower; height > 0 {
+			volume += height * hypervolume(sorted[:i+1], ref, d-1)
+		}
+	}
+	return volume
+}
+
+// ------------------------ NSGA-II ------------------------ //
+
+// NSGA2EvolutionModel реализует NSGA-II: потомки отбираются бинарным турниром по рангу фронта
+// и расстоянию скученности, следующее поколение — лучшие фронты объединения родителей и потомков
+type NSGA2EvolutionModel struct {
+	Config MultiObjectiveConfig
+
+	front       []ParetoPoint // Фронт Парето последнего поколения
+	hypervolume float64       // Гиперобъём фронта
+}
+
+func (m *NSGA2EvolutionModel) Evolve(ga *Algorithm) error {
+	if len(ga.Population) == 0 {
+		return errors.New("empty population")
+	}
+	objectives := m.Config.objectives(ga.Graph)
+
+	values := objectiveValues(ga.Population, objectives, ga.Graph)
+	rank, crowding := rankAndCrowding(values)
+
+	offspring := make([]Chromosome, 0, ga.PopulationSize)
+	for len(offspring) < ga.PopulationSize {
+		p1 := ga.Population[crowdedTournament(rank, crowding)]
+		p2 := ga.Population[crowdedTournament(rank, crowding)]
+		child := ga.CrossoverStrategy.Crossover(p1, p2)
+		ga.mutate(&child, p1, p2)
+		ga.repair(&child)
+		ga.evaluate(&child)
+		ga.reportOffspring(child)
+		offspring = append(offspring, child)
+	}
+
+	combined := append(append(make([]Chromosome, 0, len(ga.Population)+len(offspring)), ga.Population...), offspring...)
+	values = append(values, objectiveValues(offspring, objectives, ga.Graph)...)
+	fronts := NonDominatedSort(values)
+
+	next := make([]Chromosome, 0, ga.PopulationSize)
+	for _, front := range fronts {
+		if len(next)+len(front) <= ga.PopulationSize {
+			for _, i := range front {
+				next = append(next, combined[i])
+			}
+			continue
+		}
+		// Последний фронт не помещается целиком: берём наименее скученные решения
+		dist := CrowdingDistance(values, front)
+		order := make([]int, len(front))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool { return dist[order[a]] > dist[order[b]] })
+		for _, k := range order[:ga.PopulationSize-len(next)] {
+			next = append(next, combined[front[k]])
+		}
+		break
+	}
+
+	m.front = paretoPoints(combined, values, fronts[0])
+	points := make([][]float64, len(m.front))
+	for i, p := range m.front {
+		points[i] = p.Objectives
+	}
+	m.hypervolume = Hypervolume(points, m.Config.referencePoint(ga.Graph, objectives))
+
+	ga.Population = next
+	ga.SetLocalBest(ga.GetBestChromosome())
+	ga.adaptRates()
+	ga.checkStagnation()
+	ga.CurrentGeneration++
+	ga.Logger.LogGeneration(ga)
+	ga.Logger.LogInfo("Фронт Парето: %d решений, гиперобъём=%.3f", len(m.front), m.hypervolume)
+
+	if ga.ShouldTerminate() {
+		ga.Logger.LogCompletion(ga)
+	}
+	return nil
+}
+
+// objectiveValues вычисляет векторы целевых функций хромосом
+func objectiveValues(pop []Chromosome, objectives []Objective, graph *Graph) [][]float64 {
+	values := make([][]float64, len(pop))
+	for i, chrom := range pop {
+		values[i] = make([]float64, len(objectives))
+		for k, o := range objectives {
+			values[i][k] = o.Value(chrom.Genes, graph)
+		}
+	}
+	return values
+}
+
+// rankAndCrowding возвращает номер фронта и расстояние скученности каждого вектора
+func rankAndCrowding(values [][]float64) ([]int, []float64) {
+	rank := make([]int, len(values))
+	crowding := make([]float64, len(values))
+	for r, front := range NonDominatedSort(values) {
+		dist := CrowdingDistance(values, front)
+		for k, i := range front {
+			rank[i] = r
+			crowding[i] = dist[k]
+		}
+	}
+	return rank, crowding
+}
+
+// crowdedTournament выбирает индекс бинарным турниром: меньший ранг, затем большее расстояние
+func crowdedTournament(rank []int, crowding []float64) int {
+	a, b := rng.Intn(len(rank)), rng.Intn(len(rank))
+	if rank[b] < rank[a] || (rank[b] == rank[a] && crowding[b] > crowding[a]) {
+		return b
+	}
+	return a
+}
+
+// paretoPoints собирает различные по значениям целей решения фронта, упорядоченные по первой цели
+func paretoPoints(pop []Chromosome, values [][]float64, front []int) []ParetoPoint {
+	seen := make(map[string]bool)
+	var points []ParetoPoint
+	for _, i := range front {
+		key := fmt.Sprint(values[i])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		genes := make([]bool, len(pop[i].Genes))
+		copy(genes, pop[i].Genes)
+		points = append(points, ParetoPoint{Genes: genes, Objectives: values[i]})
+	}
+	sort.Slice(points, func(a, b int) bool { return points[a].Objectives[0] < points[b].Objectives[0] })
+	return points
+}
+
+func (m *NSGA2EvolutionModel) GetRequiredStrategies() []string {
+	return []string{"Crossover", "Mutation"}
+}
+
+func (m *NSGA2EvolutionModel) ValidateStrategies(ga *Algorithm) error {
+	if ga.CrossoverStrategy == nil {
+		return errors.New("crossover strategy is required for NSGA-II model")
+	}
+	if ga.MutationStrategy == nil {
+		return errors.New("mutation strategy is required for NSGA-II model")
+	}
+	return m.Config.Validate(ga.Graph)
+}
+
+func (m *NSGA2EvolutionModel) GetModelName() string {
+	return "NSGA2"
+}
+
+func (m *NSGA2EvolutionModel) String() string {
+	return "NSGA2"
+}
+
+// ObjectiveNames возвращает названия целевых функций модели NSGA-II
+func (ga *Algorithm) ObjectiveNames() []string {
+	m, ok := ga.EvolutionModel.(*NSGA2EvolutionModel)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, o := range m.Config.objectives(ga.Graph) {
+		names = append(names, o.GetName())
+	}
+	return names
+}
+
+// ParetoFront возвращает фронт Парето и его гиперобъём после последнего поколения
+// модели NSGA-II; false — модель не многокритериальная
+func (ga *Algorithm) ParetoFront() ([]ParetoPoint, float64, bool) {
+	m, ok := ga.EvolutionModel.(*NSGA2EvolutionModel)
+	if !ok {
+		return nil, 0, false
+	}
+	return m.front, m.hypervolume, true
+}
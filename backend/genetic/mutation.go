@@ -2,7 +2,6 @@ package genetic
 
 import (
 	"container/list"
-	"math/rand"
 )
 
 // -------------------------------- Classic Mutation -------------------------------- //
@@ -11,7 +10,7 @@ type ClassicMutationStrategy struct{}
 
 func (s *ClassicMutationStrategy) Mutate(chrom *Chromosome, rate float64, _ *Graph) {
 	for i := range chrom.Genes {
-		if rand.Float64() < rate {
+		if rng.Float64() < rate {
 			chrom.Genes[i] = !chrom.Genes[i]
 		}
 	}
@@ -31,7 +30,7 @@ func (s *IslandMutationStrategy) Mutate(chrom *Chromosome, rate float64, graph *
 	copy(tempGenes, chrom.Genes)
 
 	for i := range tempGenes {
-		if rand.Float64() < rate {
+		if rng.Float64() < rate {
 			tempGenes[i] = !tempGenes[i]
 		}
 	}
@@ -57,7 +56,7 @@ type SteadyStateMutationStrategy struct{}
 
 func (s *SteadyStateMutationStrategy) Mutate(chrom *Chromosome, rate float64, graph *Graph) {
 	for i := range chrom.Genes {
-		if rand.Float64() < rate {
+		if rng.Float64() < rate {
 			chrom.Genes[i] = !chrom.Genes[i]
 		}
 	}
@@ -104,7 +103,7 @@ func (s *ConflictAdaptiveMutationStrategy) Mutate(chrom *Chromosome, rate float6
 	// Мутируем каждый ген с вероятностью rate * (1 + conflicts/maxC)
 	for i := 0; i < n; i++ {
 		p := rate * (1 + float64(conflicts[i])/float64(maxC))
-		if rand.Float64() < p {
+		if rng.Float64() < p {
 			chrom.Genes[i] = !chrom.Genes[i]
 		}
 	}
@@ -124,7 +123,7 @@ type AugmentingPathMutationStrategy struct{}
 
 func (s *AugmentingPathMutationStrategy) Mutate(chrom *Chromosome, rate float64, graph *Graph) {
 	// применяем с заданной базовой вероятностью
-	if rand.Float64() > rate {
+	if rng.Float64() > rate {
 		return
 	}
 
@@ -221,6 +220,11 @@ func matchedVertex(v int, chrom *Chromosome, graph *Graph) bool {
 	return false
 }
 
+// RateScope: rate — вероятность применить оператор к особи, а не вероятность на ген
+func (s *AugmentingPathMutationStrategy) RateScope() RateScope {
+	return RatePerApplication
+}
+
 func (s *AugmentingPathMutationStrategy) GetName() string {
 	return "AugmentingPath"
 }
@@ -231,8 +235,14 @@ type CombinedMutationStrategy struct {
 	Strategies []MutationStrategy
 }
 
+// Mutate трактует rate как вероятность на ген; операторам, применяемым к особи целиком,
+// передаётся вероятность хотя бы одного изменения в геноме
 func (s *CombinedMutationStrategy) Mutate(chrom *Chromosome, rate float64, graph *Graph) {
 	for _, strategy := range s.Strategies {
+		if MutationRateScope(strategy) == RatePerApplication {
+			strategy.Mutate(chrom, applicationRate(rate, len(chrom.Genes)), graph)
+			continue
+		}
 		strategy.Mutate(chrom, rate, graph)
 	}
 }
@@ -0,0 +1,177 @@
+package genetic
+
+// OperatorAdaptation задаёт способ адаптивного выбора под-операторов
+type OperatorAdaptation int
+
+const (
+	// AdaptationNone — фиксированные веса, заданные пользователем
+	AdaptationNone OperatorAdaptation = iota
+	// ProbabilityMatching — вероятности пропорциональны накопленному качеству оператора
+	ProbabilityMatching
+	// AdaptivePursuit — вероятность лучшего оператора «преследует» PMax, остальных — PMin
+	AdaptivePursuit
+)
+
+func (a OperatorAdaptation) String() string {
+	switch a {
+	case AdaptationNone:
+		return "None"
+	case ProbabilityMatching:
+		return "ProbabilityMatching"
+	case AdaptivePursuit:
+		return "AdaptivePursuit"
+	default:
+		return "Unknown"
+	}
+}
+
+// OffspringFeedback реализуется операторами, которым нужна обратная связь
+// о приспособленности потомка после мутации, починки и оценки
+type OffspringFeedback interface {
+	Feedback(child Chromosome)
+}
+
+// OperatorStats содержит статистику кредитования одного под-оператора
+type OperatorStats struct {
+	Name        string  // Имя под-оператора
+	Probability float64 // Текущая вероятность выбора
+	Quality     float64 // Оценка качества (экспоненциальное среднее награды)
+	Uses        int     // Сколько раз оператор был применён
+	Successes   int     // Сколько потомков оказались лучше родителей
+	TotalReward float64 // Суммарная награда (прирост фитнеса)
+}
+
+// operatorSelector хранит вероятности под-операторов и обновляет их по наградам
+type operatorSelector struct {
+	adaptation OperatorAdaptation
+	alpha      float64 // Скорость адаптации оценки качества
+	beta       float64 // Скорость преследования (только для AdaptivePursuit)
+	pMin       float64 // Минимальная вероятность выбора оператора
+	probs      []float64
+	stats      []OperatorStats
+}
+
+func newOperatorSelector(names []string, weights []float64, adaptation OperatorAdaptation) *operatorSelector {
+	s := &operatorSelector{
+		adaptation: adaptation,
+		alpha:      0.3,
+		beta:       0.3,
+		probs:      make([]float64, len(names)),
+		stats:      make([]OperatorStats, len(names)),
+	}
+	if len(names) > 0 {
+		s.pMin = 0.1 / float64(len(names))
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	for i, name := range names {
+		s.stats[i].Name = name
+		switch {
+		case total == 0:
+			s.probs[i] = 1 / float64(len(names))
+		case weights[i] > 0:
+			s.probs[i] = weights[i] / total
+		}
+	}
+	// Начальное качество равно весу, чтобы адаптация стартовала с заданных пропорций
+	for i := range s.stats {
+		s.stats[i].Quality = s.probs[i]
+	}
+	s.syncStats()
+	return s
+}
+
+// pick выбирает индекс оператора пропорционально текущим вероятностям
+func (s *operatorSelector) pick() int {
+	r := rng.Float64()
+	cumulative := 0.0
+	for i, p := range s.probs {
+		cumulative += p
+		if r < cumulative {
+			return i
+		}
+	}
+	return len(s.probs) - 1
+}
+
+// reward начисляет награду оператору idx и пересчитывает вероятности
+func (s *operatorSelector) reward(idx int, reward float64) {
+	st := &s.stats[idx]
+	st.Uses++
+	if reward > 0 {
+		st.Successes++
+		st.TotalReward += reward
+	}
+	st.Quality += s.alpha * (reward - st.Quality)
+
+	switch s.adaptation {
+	case ProbabilityMatching:
+		s.probabilityMatching()
+	case AdaptivePursuit:
+		s.adaptivePursuit()
+	}
+	s.syncStats()
+}
+
+func (s *operatorSelector) probabilityMatching() {
+	k := float64(len(s.probs))
+	total := 0.0
+	for _, st := range s.stats {
+		total += st.Quality
+	}
+	for i, st := range s.stats {
+		if total <= 0 {
+			s.probs[i] = 1 / k
+			continue
+		}
+		s.probs[i] = s.pMin + (1-k*s.pMin)*st.Quality/total
+	}
+}
+
+func (s *operatorSelector) adaptivePursuit() {
+	k := float64(len(s.probs))
+	pMax := 1 - (k-1)*s.pMin
+	best := 0
+	for i, st := range s.stats {
+		if st.Quality > s.stats[best].Quality {
+			best = i
+		}
+	}
+	for i := range s.probs {
+		if i == best {
+			s.probs[i] += s.beta * (pMax - s.probs[i])
+		} else {
+			s.probs[i] += s.beta * (s.pMin - s.probs[i])
+		}
+	}
+}
+
+func (s *operatorSelector) syncStats() {
+	for i := range s.stats {
+		s.stats[i].Probability = s.probs[i]
+	}
+}
+
+// snapshot возвращает копию статистики
+func (s *operatorSelector) snapshot() []OperatorStats {
+	out := make([]OperatorStats, len(s.stats))
+	copy(out, s.stats)
+	return out
+}
+
+// reportOffspring передаёт потомка операторам, ожидающим обратной связи
+func (ga *Algorithm) reportOffspring(child Chromosome) {
+	reportOffspringTo(ga.CrossoverStrategy, child)
+	ga.observeOffspring(child)
+}
+
+func reportOffspringTo(crossover CrossoverStrategy, child Chromosome) {
+	if fb, ok := crossover.(OffspringFeedback); ok {
+		fb.Feedback(child)
+	}
+}
@@ -1,41 +1,70 @@
 package genetic
 
-import (
-	"math/rand"
-)
-
 // Chromosome – хромосома, кодирующая решение в виде булевого среза.
 // Значение true означает, что соответствующее ребро включено в паросочетание.
 type Chromosome struct {
-	Genes   []bool
-	Fitness int
+	Genes        []bool
+	Fitness      int
+	MutationRate float64 // Собственная вероятность мутации при самоадаптации (0 — общая)
+
+	origin *crossoverOrigin // Под-оператор комбинированного кроссовера, породивший особь (nil — нет)
 }
 
 // InitializePopulation генерирует начальную популяцию
 func (ga *Algorithm) InitializePopulation() {
+	cfg := ga.ModelConfig.Initialization
+	seeds := cfg.seedCount(ga.PopulationSize)
 	ga.Population = make([]Chromosome, ga.PopulationSize)
 	for i := range ga.Population {
-		ga.Population[i] = ga.GenerateChromosome()
+		if i < seeds {
+			ga.Population[i] = ga.seed(i)
+		} else {
+			ga.Population[i] = ga.GenerateChromosome()
+		}
 	}
 }
 
 func (ga *Algorithm) InitializeIslands() [][]Chromosome {
 	ga.InitializePopulation()
-	return ga.DistributePopulation()
+	ga.ResetIslands()
+	return ga.Islands
+}
+
+// ResetIslands заново распределяет текущую популяцию по островам.
+// Острова сохраняются между поколениями, поэтому вызывать нужно только
+// после замены популяции целиком.
+func (ga *Algorithm) ResetIslands() {
+	ga.Islands = nil
+	for _, island := range ga.DistributePopulation() {
+		own := make([]Chromosome, len(island))
+		copy(own, island)
+		ga.Islands = append(ga.Islands, own)
+	}
 }
 
 // ------------------------ Main ------------------------- //
 
 // GenerateChromosome создаёт новую хромосому с корректным фитнесом
+// инициализатором, выбранным из смеси ModelConfig.Initialization.
+// Инициализаторы строят паросочетания, поэтому другие задачи начинают со случайных починенных геномов.
 func (ga *Algorithm) GenerateChromosome() Chromosome {
-	numEdges := len(ga.Graph.Edges)
-	genes := make([]bool, numEdges)
-	for j := 0; j < numEdges; j++ {
-		genes[j] = rand.Float64() < 0.5
+	if ga.Problem.Type() != Matching {
+		return randomSolution(ga.Problem)
 	}
-	chrom := Chromosome{Genes: genes}
-	RepairFast(&chrom, ga.Graph)
-	Evaluate(&chrom, ga.Graph)
+	return ga.ModelConfig.Initialization.pick().Generate(ga.Graph)
+}
+
+// seed возвращает починенную и оценённую копию i-го затравочного генома (по кругу).
+// Паросочетания чинятся детерминированно, чтобы затравка сохранялась без изменений.
+func (ga *Algorithm) seed(i int) Chromosome {
+	seeds := ga.ModelConfig.Initialization.Seeds
+	chrom := copyChromosome(Chromosome{Genes: seeds[i%len(seeds)]})
+	if ga.Problem.Type() == Matching {
+		RepairFast(&chrom, ga.Graph)
+	} else {
+		ga.Problem.Repair(&chrom)
+	}
+	ga.Problem.Evaluate(&chrom)
 	return chrom
 }
 
@@ -65,17 +94,33 @@ func (ga *Algorithm) GetIslandBest(island []Chromosome) Chromosome {
 
 // SetBestSoFar обновляет лучшее решение за всё время
 func (ga *Algorithm) SetBestSoFar(chrom Chromosome) {
-	edges := countValidMatchingEdges(chrom, ga.Graph)
-	if edges > ga.BestSoFarEdges {
+	if ga.IsBetterThanBestSoFar(chrom) {
 		ga.bestSoFar = chrom
-		ga.BestSoFarEdges = edges
+		ga.BestSoFarEdges = ga.Problem.Objective(chrom.Genes)
 	}
 }
 
+// IsBetterThanBestSoFar сообщает, улучшает ли chrom лучшее решение за всё время:
+// больше рёбер, а для взвешенного графа — также равное число рёбер с меньшей стоимостью.
+// Для других задач сравнивается приспособленность допустимых решений.
+func (ga *Algorithm) IsBetterThanBestSoFar(chrom Chromosome) bool {
+	if ga.Problem.Type() != Matching {
+		if !ga.Problem.Feasible(chrom.Genes) {
+			return false
+		}
+		return ga.bestSoFar.Genes == nil || chrom.Fitness > ga.bestSoFar.Fitness
+	}
+	edges := countValidMatchingEdges(chrom, ga.Graph)
+	if edges != ga.BestSoFarEdges || ga.Graph.Costs == nil {
+		return edges > ga.BestSoFarEdges
+	}
+	return chrom.Fitness > ga.bestSoFar.Fitness
+}
+
 // SetLocalBest обновляет лучший результат в текущей популяции
 func (ga *Algorithm) SetLocalBest(chrom Chromosome) {
 	ga.localBest = chrom
-	ga.LocalBestEdges = countValidMatchingEdges(chrom, ga.Graph)
+	ga.LocalBestEdges = ga.Problem.Objective(chrom.Genes)
 }
 
 // GetBestSoFar возвращает глобально лучшее решение
@@ -109,34 +154,9 @@ func (ga *Algorithm) GetAllBestChromosomes() []Chromosome {
 
 // ----------------------- Island ----------------------- //
 
-// MigrateIslands выполняет обмен лучшими особями между островами.
+// MigrateIslands выполняет обмен лучшими особями между островами по кольцу.
 func MigrateIslands(islands [][]Chromosome) [][]Chromosome {
-	numIslands := len(islands)
-	bests := make([]Chromosome, numIslands)
-
-	for i := 0; i < numIslands; i++ {
-		bests[i] = islands[i][0]
-		for _, chrom := range islands[i] {
-			if chrom.Fitness > bests[i].Fitness {
-				bests[i] = chrom
-			}
-		}
-	}
-
-	for i := 0; i < numIslands; i++ {
-		sourceIndex := (i - 1 + numIslands) % numIslands
-		minFitness := islands[i][0].Fitness
-		minIdx := 0
-		for j, chrom := range islands[i] {
-			if chrom.Fitness < minFitness {
-				minFitness = chrom.Fitness
-				minIdx = j
-			}
-		}
-		islands[i][minIdx] = bests[sourceIndex]
-	}
-
-	return islands
+	return MigrateIslandsWith(islands, &RingTopology{}, MigrationPolicy{Migrants: 1})
 }
 
 // MergeIslands объединяет популяции всех островов.
@@ -153,7 +173,7 @@ func (ga *Algorithm) DistributePopulation() [][]Chromosome {
 	islands := make([][]Chromosome, ga.NumIslands)
 	islandSize := ga.PopulationSize / ga.NumIslands
 
-	rand.Shuffle(ga.PopulationSize, func(i, j int) {
+	rng.Shuffle(ga.PopulationSize, func(i, j int) {
 		ga.Population[i], ga.Population[j] = ga.Population[j], ga.Population[i]
 	})
 
@@ -0,0 +1,717 @@
+package genetic
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// ProblemType определяет оптимизационную задачу на графе
+type ProblemType int
+
+const (
+	// Matching — наибольшее паросочетание (взвешенное и с ёмкостями вершин); гены — рёбра
+	Matching ProblemType = iota
+	// IndependentSet — наибольшее независимое множество; гены — вершины
+	IndependentSet
+	// VertexCover — наименьшее вершинное покрытие; гены — вершины
+	VertexCover
+	// MaxCut — наибольший разрез; ген вершины задаёт её долю
+	MaxCut
+	// EdgeDominatingSet — наименьшее доминирующее множество рёбер; гены — рёбра
+	EdgeDominatingSet
+	// StableMatching — паросочетание без блокирующих пар по спискам предпочтений; гены — рёбра
+	StableMatching
+)
+
+func (p ProblemType) String() string {
+	switch p {
+	case Matching:
+		return "Matching"
+	case IndependentSet:
+		return "Independent Set"
+	case VertexCover:
+		return "Vertex Cover"
+	case MaxCut:
+		return "Max Cut"
+	case EdgeDominatingSet:
+		return "Edge Dominating Set"
+	case StableMatching:
+		return "Stable Matching"
+	default:
+		return "Unknown"
+	}
+}
+
+// EncodesVertices сообщает, соответствуют ли гены задачи вершинам (иначе — рёбрам)
+func (p ProblemType) EncodesVertices() bool {
+	return p == IndependentSet || p == VertexCover || p == MaxCut
+}
+
+// GenomeLength возвращает длину генома задачи на графе
+func (p ProblemType) GenomeLength(graph *Graph) int {
+	if p.EncodesVertices() {
+		return graph.NumVertices
+	}
+	return len(graph.Edges)
+}
+
+// Problem связывает геном с задачей на конкретном графе. Приспособленность всегда
+// максимизируется; задачи минимизации переводят размер решения в приспособленность сами.
+type Problem interface {
+	Type() ProblemType
+	GenomeLength() int
+	// Evaluate записывает приспособленность допустимой части решения
+	Evaluate(chrom *Chromosome)
+	// Repair приводит хромосому к допустимому решению; приспособленность не пересчитывается
+	Repair(chrom *Chromosome)
+	Feasible(genes []bool) bool
+	// Objective возвращает значение целевой функции допустимой части решения:
+	// размер паросочетания, множества или покрытия, число рёбер разреза
+	Objective(genes []bool) int
+	// ReferenceOptimum возвращает приспособленность оптимума, если она вычислима для этого графа
+	ReferenceOptimum() (int, bool)
+	DescribeSolution(genes []bool) string
+}
+
+// Пределы размера графа для точных эталонных решений
+const (
+	exactVertexLimit = 64      // Независимое множество и покрытие (битовые маски)
+	exactCutLimit    = 20      // Полный перебор разрезов
+	exactEDSLimit    = 24      // Перебор наименьших максимальных паросочетаний
+	exactEDSNodes    = 100_000 // Наибольшее число узлов этого перебора
+)
+
+// NewProblem создаёт задачу заданного типа на графе. Для паросочетания repair
+// задаёт стратегию починки (nil — по индексам), для остальных задач не используется.
+func NewProblem(kind ProblemType, graph *Graph, repair RepairStrategy) (Problem, error) {
+	if kind != Matching && (graph.Costs != nil || graph.Capacities != nil) {
+		return nil, fmt.Errorf("edge costs and vertex capacities apply only to matching, not to %v", kind)
+	}
+	switch kind {
+	case Matching:
+		return newMatchingProblem(graph, repair)
+	case IndependentSet:
+		return &IndependentSetProblem{vertexProblem: newVertexProblem(graph)}, nil
+	case VertexCover:
+		return &VertexCoverProblem{vertexProblem: newVertexProblem(graph)}, nil
+	case MaxCut:
+		return &MaxCutProblem{vertexProblem: newVertexProblem(graph)}, nil
+	case EdgeDominatingSet:
+		return &EdgeDominatingSetProblem{graph: graph}, nil
+	case StableMatching:
+		return newStableMatchingProblem(graph)
+	default:
+		return nil, fmt.Errorf("unknown problem type %d", kind)
+	}
+}
+
+// checkProblemOperators запрещает операторы, которые чинят и оценивают хромосому как паросочетание
+func checkProblemOperators(kind ProblemType, selection SelectionStrategy, mutation MutationStrategy) error {
+	if kind == Matching {
+		return nil
+	}
+	switch mutation.(type) {
+	case *IslandMutationStrategy, *ConflictAdaptiveMutationStrategy, *AugmentingPathMutationStrategy, *CombinedMutationStrategy:
+		return fmt.Errorf("mutation %s is specific to matching and cannot be used for %v", mutation.GetName(), kind)
+	}
+	if _, ok := selection.(*LexicaseSelectionStrategy); ok {
+		return fmt.Errorf("selection %s is specific to matching and cannot be used for %v", selection.GetName(), kind)
+	}
+	return nil
+}
+
+// randomSolution создаёт починенную и оценённую случайную хромосому задачи
+func randomSolution(p Problem) Chromosome {
+	chrom := Chromosome{Genes: make([]bool, p.GenomeLength())}
+	for i := range chrom.Genes {
+		chrom.Genes[i] = rng.Intn(2) == 1
+	}
+	p.Repair(&chrom)
+	p.Evaluate(&chrom)
+	return chrom
+}
+
+// hillClimb улучшает хромосому переворотом отдельных генов с починкой (первое улучшение)
+// и выполняет до maxPasses проходов по генам в случайном порядке
+func hillClimb(p Problem, chrom *Chromosome, maxPasses int) {
+	p.Repair(chrom)
+	p.Evaluate(chrom)
+	for pass := 0; pass < maxPasses; pass++ {
+		improved := false
+		for _, i := range rng.Perm(len(chrom.Genes)) {
+			candidate := copyChromosome(*chrom)
+			candidate.Genes[i] = !candidate.Genes[i]
+			p.Repair(&candidate)
+			p.Evaluate(&candidate)
+			if candidate.Fitness > chrom.Fitness {
+				candidate.origin = chrom.origin
+				*chrom = candidate
+				improved = true
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+// problemHillClimb — LocalSearch одного прохода hillClimb для задачи, отличной от паросочетания
+type problemHillClimb struct {
+	problem Problem
+}
+
+func (h problemHillClimb) Improve(chrom *Chromosome, _ *Graph) { hillClimb(h.problem, chrom, 1) }
+func (h problemHillClimb) GetName() string                     { return "BitFlipHillClimb" }
+
+// describeIndices перечисляет выбранные индексы (не более 20)
+func describeIndices(genes []bool) string {
+	var parts []string
+	for i, on := range genes {
+		if on {
+			if len(parts) == 20 {
+				parts = append(parts, "...")
+				break
+			}
+			parts = append(parts, fmt.Sprint(i))
+		}
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// ------------------------ Паросочетание ------------------------ //
+
+// MatchingProblem — наибольшее паросочетание, b-паросочетание или назначение наименьшей стоимости
+type MatchingProblem struct {
+	graph     *Graph
+	repair    RepairStrategy
+	optimum   int
+	reference *AssignmentSolution // Оптимум венгерского алгоритма (только для взвешенного графа)
+}
+
+func newMatchingProblem(graph *Graph, repair RepairStrategy) (*MatchingProblem, error) {
+	if repair == nil {
+		repair = &IndexOrderRepair{}
+	}
+	p := &MatchingProblem{graph: graph, repair: repair}
+
+	// Для взвешенного графа эталон — назначение венгерского алгоритма
+	if graph.Costs != nil {
+		ref, err := SolveAssignment(graph)
+		if err != nil {
+			return nil, fmt.Errorf("weighted matching: %w", err)
+		}
+		chrom := Chromosome{Genes: ref.Genes}
+		Evaluate(&chrom, graph)
+		p.reference = &ref
+		p.optimum = chrom.Fitness
+		return p, nil
+	}
+	p.optimum = MaxMatching(graph)
+	return p, nil
+}
+
+func (p *MatchingProblem) Type() ProblemType          { return Matching }
+func (p *MatchingProblem) GenomeLength() int          { return len(p.graph.Edges) }
+func (p *MatchingProblem) Evaluate(chrom *Chromosome) { Evaluate(chrom, p.graph) }
+func (p *MatchingProblem) Repair(chrom *Chromosome)   { p.repair.Repair(chrom, p.graph) }
+
+func (p *MatchingProblem) Feasible(genes []bool) bool {
+	return len(ValidMatchingEdges(genes, p.graph)) == countTrue(genes)
+}
+
+func (p *MatchingProblem) Objective(genes []bool) int {
+	return len(ValidMatchingEdges(genes, p.graph))
+}
+
+func (p *MatchingProblem) ReferenceOptimum() (int, bool) {
+	return p.optimum, true
+}
+
+func (p *MatchingProblem) DescribeSolution(genes []bool) string {
+	size, cost := MatchingCost(genes, p.graph)
+	if p.graph.Costs != nil {
+		return fmt.Sprintf("matching of %d edges, cost %.3f", size, cost)
+	}
+	return fmt.Sprintf("matching of %d edges", size)
+}
+
+// ------------------------ Задачи на вершинах ------------------------ //
+
+// vertexProblem хранит списки смежности и степени для задач с генами-вершинами
+type vertexProblem struct {
+	graph  *Graph
+	adj    [][]int
+	degree []int
+}
+
+func newVertexProblem(graph *Graph) vertexProblem {
+	vp := vertexProblem{
+		graph:  graph,
+		adj:    make([][]int, graph.NumVertices),
+		degree: make([]int, graph.NumVertices),
+	}
+	for _, e := range graph.Edges {
+		if e.U == e.V {
+			continue
+		}
+		vp.adj[e.U] = append(vp.adj[e.U], e.V)
+		vp.adj[e.V] = append(vp.adj[e.V], e.U)
+		vp.degree[e.U]++
+		vp.degree[e.V]++
+	}
+	return vp
+}
+
+func (vp vertexProblem) GenomeLength() int { return vp.graph.NumVertices }
+
+// neighborMasks возвращает окрестности вершин битовыми масками (граф не больше exactVertexLimit)
+func (vp vertexProblem) neighborMasks() []uint64 {
+	masks := make([]uint64, vp.graph.NumVertices)
+	for v, list := range vp.adj {
+		for _, w := range list {
+			masks[v] |= 1 << uint(w)
+		}
+	}
+	return masks
+}
+
+// maxIndependentSetSize находит размер наибольшего независимого множества ветвлением:
+// вершины степени не больше 1 берутся сразу, иначе ветвление по вершине наибольшей степени
+func maxIndependentSetSize(masks []uint64) int {
+	best := 0
+	var rec func(cand uint64, size int)
+	rec = func(cand uint64, size int) {
+		if size+bits.OnesCount64(cand) <= best {
+			return
+		}
+		if cand == 0 {
+			best = size
+			return
+		}
+		minV, maxV := -1, -1
+		minD, maxD := 0, -1
+		for rest := cand; rest != 0; rest &= rest - 1 {
+			v := bits.TrailingZeros64(rest)
+			d := bits.OnesCount64(masks[v] & cand)
+			if minV < 0 || d < minD {
+				minV, minD = v, d
+			}
+			if d > maxD {
+				maxV, maxD = v, d
+			}
+		}
+		if minD <= 1 {
+			rec(cand&^(1<<uint(minV)|masks[minV]), size+1)
+			return
+		}
+		rec(cand&^(1<<uint(maxV)|masks[maxV]), size+1)
+		rec(cand&^(1<<uint(maxV)), size)
+	}
+	all := uint64(0)
+	for v := range masks {
+		all |= 1 << uint(v)
+	}
+	rec(all, 0)
+	return best
+}
+
+// IndependentSetProblem — наибольшее множество попарно несмежных вершин
+type IndependentSetProblem struct {
+	vertexProblem
+}
+
+func (p *IndependentSetProblem) Type() ProblemType { return IndependentSet }
+
+// validPart оставляет выбранные вершины по возрастанию номера, пока они не смежны с уже оставленными
+func (p *IndependentSetProblem) validPart(genes []bool) []bool {
+	kept := make([]bool, len(genes))
+	for v, on := range genes {
+		if !on {
+			continue
+		}
+		kept[v] = true
+		for _, w := range p.adj[v] {
+			if w < v && kept[w] {
+				kept[v] = false
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func (p *IndependentSetProblem) Evaluate(chrom *Chromosome) {
+	chrom.Fitness = p.Objective(chrom.Genes)
+}
+
+// Repair удаляет из каждого конфликтного ребра конец большей степени
+func (p *IndependentSetProblem) Repair(chrom *Chromosome) {
+	for _, e := range p.graph.Edges {
+		if e.U == e.V || !chrom.Genes[e.U] || !chrom.Genes[e.V] {
+			continue
+		}
+		drop := e.U
+		if p.degree[e.V] > p.degree[e.U] || (p.degree[e.V] == p.degree[e.U] && e.V > e.U) {
+			drop = e.V
+		}
+		chrom.Genes[drop] = false
+	}
+}
+
+func (p *IndependentSetProblem) Feasible(genes []bool) bool {
+	for _, e := range p.graph.Edges {
+		if genes[e.U] && genes[e.V] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *IndependentSetProblem) Objective(genes []bool) int {
+	return countTrue(p.validPart(genes))
+}
+
+func (p *IndependentSetProblem) ReferenceOptimum() (int, bool) {
+	if p.graph.NumVertices > exactVertexLimit {
+		return 0, false
+	}
+	return maxIndependentSetSize(p.neighborMasks()), true
+}
+
+func (p *IndependentSetProblem) DescribeSolution(genes []bool) string {
+	kept := p.validPart(genes)
+	return fmt.Sprintf("independent set of %d vertices %s", countTrue(kept), describeIndices(kept))
+}
+
+// VertexCoverProblem — наименьшее множество вершин, покрывающее все рёбра.
+// Приспособленность — число вершин вне покрытия.
+type VertexCoverProblem struct {
+	vertexProblem
+}
+
+func (p *VertexCoverProblem) Type() ProblemType { return VertexCover }
+
+// completed дополняет выбор до покрытия: для каждого непокрытого ребра берётся конец большей степени
+func (p *VertexCoverProblem) completed(genes []bool) []bool {
+	cover := make([]bool, len(genes))
+	copy(cover, genes)
+	for _, e := range p.graph.Edges {
+		if cover[e.U] || cover[e.V] {
+			continue
+		}
+		if p.degree[e.V] > p.degree[e.U] {
+			cover[e.V] = true
+		} else {
+			cover[e.U] = true
+		}
+	}
+	return cover
+}
+
+func (p *VertexCoverProblem) Evaluate(chrom *Chromosome) {
+	chrom.Fitness = p.graph.NumVertices - p.Objective(chrom.Genes)
+}
+
+// Repair дополняет выбор до покрытия и удаляет в случайном порядке вершины,
+// все соседи которых уже входят в покрытие
+func (p *VertexCoverProblem) Repair(chrom *Chromosome) {
+	copy(chrom.Genes, p.completed(chrom.Genes))
+	for _, v := range rng.Perm(len(chrom.Genes)) {
+		if !chrom.Genes[v] {
+			continue
+		}
+		redundant := true
+		for _, w := range p.adj[v] {
+			if !chrom.Genes[w] {
+				redundant = false
+				break
+			}
+		}
+		if redundant {
+			chrom.Genes[v] = false
+		}
+	}
+}
+
+func (p *VertexCoverProblem) Feasible(genes []bool) bool {
+	for _, e := range p.graph.Edges {
+		if !genes[e.U] && !genes[e.V] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *VertexCoverProblem) Objective(genes []bool) int {
+	return countTrue(p.completed(genes))
+}
+
+// ReferenceOptimum использует то, что дополнение покрытия — независимое множество
+func (p *VertexCoverProblem) ReferenceOptimum() (int, bool) {
+	if p.graph.NumVertices > exactVertexLimit {
+		return 0, false
+	}
+	return maxIndependentSetSize(p.neighborMasks()), true
+}
+
+func (p *VertexCoverProblem) DescribeSolution(genes []bool) string {
+	cover := p.completed(genes)
+	return fmt.Sprintf("vertex cover of %d vertices %s", countTrue(cover), describeIndices(cover))
+}
+
+// MaxCutProblem — разбиение вершин на две доли с наибольшим числом рёбер между ними.
+// Любой геном допустим.
+type MaxCutProblem struct {
+	vertexProblem
+}
+
+func (p *MaxCutProblem) Type() ProblemType { return MaxCut }
+
+func (p *MaxCutProblem) Evaluate(chrom *Chromosome) {
+	chrom.Fitness = p.Objective(chrom.Genes)
+}
+
+func (p *MaxCutProblem) Repair(chrom *Chromosome) {}
+
+func (p *MaxCutProblem) Feasible(genes []bool) bool { return true }
+
+func (p *MaxCutProblem) Objective(genes []bool) int {
+	cut := 0
+	for _, e := range p.graph.Edges {
+		if genes[e.U] != genes[e.V] {
+			cut++
+		}
+	}
+	return cut
+}
+
+// ReferenceOptimum перебирает все разрезы; вершина 0 закреплена в первой доле
+func (p *MaxCutProblem) ReferenceOptimum() (int, bool) {
+	n := p.graph.NumVertices
+	if n > exactCutLimit {
+		return 0, false
+	}
+	best := 0
+	for mask := 0; mask < 1<<uint(max(n-1, 0)); mask++ {
+		side := mask << 1
+		cut := 0
+		for _, e := range p.graph.Edges {
+			if (side>>uint(e.U))&1 != (side>>uint(e.V))&1 {
+				cut++
+			}
+		}
+		if cut > best {
+			best = cut
+		}
+	}
+	return best, true
+}
+
+func (p *MaxCutProblem) DescribeSolution(genes []bool) string {
+	return fmt.Sprintf("cut of %d edges, side %s", p.Objective(genes), describeIndices(genes))
+}
+
+// ------------------- Доминирующее множество рёбер ------------------- //
+
+// EdgeDominatingSetProblem — наименьшее множество рёбер, смежное с каждым ребром графа.
+// Приспособленность — число рёбер вне множества.
+type EdgeDominatingSetProblem struct {
+	graph *Graph
+}
+
+func (p *EdgeDominatingSetProblem) Type() ProblemType { return EdgeDominatingSet }
+func (p *EdgeDominatingSetProblem) GenomeLength() int { return len(p.graph.Edges) }
+
+// completed дополняет выбор: каждое недоминируемое ребро (по возрастанию индекса) добавляется само
+func (p *EdgeDominatingSetProblem) completed(genes []bool) []bool {
+	set := make([]bool, len(genes))
+	copy(set, genes)
+	touched := make([]bool, p.graph.NumVertices)
+	for i, on := range set {
+		if on {
+			e := p.graph.Edges[i]
+			touched[e.U], touched[e.V] = true, true
+		}
+	}
+	for i, e := range p.graph.Edges {
+		if !touched[e.U] && !touched[e.V] {
+			set[i] = true
+			touched[e.U], touched[e.V] = true, true
+		}
+	}
+	return set
+}
+
+func (p *EdgeDominatingSetProblem) Evaluate(chrom *Chromosome) {
+	chrom.Fitness = len(p.graph.Edges) - p.Objective(chrom.Genes)
+}
+
+// Repair дополняет выбор до доминирующего и удаляет в случайном порядке рёбра,
+// без которых все рёбра у их концов остаются доминируемыми
+func (p *EdgeDominatingSetProblem) Repair(chrom *Chromosome) {
+	copy(chrom.Genes, p.completed(chrom.Genes))
+
+	count := make([]int, p.graph.NumVertices) // Число выбранных рёбер у вершины
+	incident := make([][]int, p.graph.NumVertices)
+	for i, e := range p.graph.Edges {
+		incident[e.U] = append(incident[e.U], i)
+		incident[e.V] = append(incident[e.V], i)
+		if chrom.Genes[i] {
+			count[e.U]++
+			count[e.V]++
+		}
+	}
+	for _, i := range rng.Perm(len(chrom.Genes)) {
+		if !chrom.Genes[i] {
+			continue
+		}
+		e := p.graph.Edges[i]
+		count[e.U]--
+		count[e.V]--
+		dominated := true
+		for _, v := range []int{e.U, e.V} {
+			for _, j := range incident[v] {
+				f := p.graph.Edges[j]
+				if count[f.U] == 0 && count[f.V] == 0 {
+					dominated = false
+				}
+			}
+		}
+		if dominated {
+			chrom.Genes[i] = false
+		} else {
+			count[e.U]++
+			count[e.V]++
+		}
+	}
+}
+
+func (p *EdgeDominatingSetProblem) Feasible(genes []bool) bool {
+	touched := make([]bool, p.graph.NumVertices)
+	for i, on := range genes {
+		if on {
+			e := p.graph.Edges[i]
+			touched[e.U], touched[e.V] = true, true
+		}
+	}
+	for _, e := range p.graph.Edges {
+		if !touched[e.U] && !touched[e.V] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *EdgeDominatingSetProblem) Objective(genes []bool) int {
+	return countTrue(p.completed(genes))
+}
+
+// ReferenceOptimum ищет наименьшее максимальное паросочетание: его размер равен размеру
+// наименьшего доминирующего множества рёбер. Ветвление идёт по рёбрам, покрывающим
+// первое недоминируемое ребро; верхняя граница — жадное максимальное паросочетание.
+// Перебор выполняется при создании алгоритма и не прерывается, поэтому он ограничен
+// exactEDSNodes узлами: если их не хватило, оптимум считается неизвестным.
+func (p *EdgeDominatingSetProblem) ReferenceOptimum() (int, bool) {
+	g := p.graph
+	if g.NumVertices > exactEDSLimit {
+		return 0, false
+	}
+	// Свободные (не покрытые паросочетанием) вершины и окрестности — битовые маски
+	neighbors := make([]uint64, g.NumVertices)
+	for _, e := range g.Edges {
+		if e.U != e.V {
+			neighbors[e.U] |= 1 << uint(e.V)
+			neighbors[e.V] |= 1 << uint(e.U)
+		}
+	}
+	all := uint64(1)<<uint(g.NumVertices) - 1
+
+	best := greedyMatchingSize(neighbors, all)
+	nodes := 0
+	var rec func(free uint64, size int)
+	rec = func(free uint64, size int) {
+		if nodes++; nodes > exactEDSNodes {
+			return
+		}
+		if size+edsLowerBound(neighbors, free) >= best {
+			return
+		}
+		// Первое ребро со свободными концами ещё не доминируется
+		u := -1
+		for rest := free; rest != 0; rest &= rest - 1 {
+			if v := bits.TrailingZeros64(rest); neighbors[v]&free != 0 {
+				u = v
+				break
+			}
+		}
+		if u < 0 {
+			best = size
+			return
+		}
+		v := bits.TrailingZeros64(neighbors[u] & free)
+		// Ребро (u, v) доминируется, только если паросочетание покроет u или v
+		for _, end := range []int{u, v} {
+			for rest := neighbors[end] & free; rest != 0; rest &= rest - 1 {
+				w := bits.TrailingZeros64(rest)
+				if end == v && w == u {
+					continue
+				}
+				rec(free&^(1<<uint(end)|1<<uint(w)), size+1)
+			}
+		}
+	}
+	rec(all, 0)
+	if nodes > exactEDSNodes {
+		return 0, false
+	}
+	return len(g.Edges) - best, true
+}
+
+// greedyMatchingSize возвращает размер жадного максимального паросочетания на вершинах free
+func greedyMatchingSize(neighbors []uint64, free uint64) int {
+	n := 0
+	for rest := free; rest != 0; rest &= rest - 1 {
+		v := bits.TrailingZeros64(rest)
+		if cand := neighbors[v] & rest &^ (1 << uint(v)); cand != 0 {
+			rest &^= 1 << uint(bits.TrailingZeros64(cand))
+			n++
+		}
+	}
+	return n
+}
+
+// edsLowerBound оценивает снизу, сколько рёбер нужно добавить к паросочетанию, чтобы оно стало
+// максимальным. Каждое ребро паросочетания на свободных вершинах доминирует не больше двух рёбер
+// любого паросочетания на них. Кроме того, оставшиеся свободными вершины независимы, а число
+// клик в жадном покрытии ограничивает сверху размер независимого множества.
+func edsLowerBound(neighbors []uint64, free uint64) int {
+	cliques := 0
+	for rest := free; rest != 0; cliques++ {
+		clique := uint64(0)
+		for cand := rest; cand != 0; {
+			v := bits.TrailingZeros64(cand)
+			clique |= 1 << uint(v)
+			cand &= neighbors[v]
+		}
+		rest &^= clique
+	}
+	byCliques := (bits.OnesCount64(free) - cliques + 1) / 2
+	byMatching := (greedyMatchingSize(neighbors, free) + 1) / 2
+	return max(byCliques, byMatching)
+}
+
+func (p *EdgeDominatingSetProblem) DescribeSolution(genes []bool) string {
+	set := p.completed(genes)
+	return fmt.Sprintf("edge dominating set of %d edges %s", countTrue(set), describeIndices(set))
+}
+
+func countTrue(genes []bool) int {
+	n := 0
+	for _, on := range genes {
+		if on {
+			n++
+		}
+	}
+	return n
+}
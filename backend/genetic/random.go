@@ -0,0 +1,57 @@
+package genetic
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ------------------------ Генератор случайных чисел ------------------------ //
+
+// Операторы движка берут случайность из rng, а не из функций пакета math/rand: начиная
+// с Go 1.24 rand.Seed ничего не делает, и воспроизвести запуск иначе нельзя. Генератор общий
+// для всех алгоритмов процесса и защищён мьютексом, поэтому запуск воспроизводим по seed,
+// если одновременно с ним не работают другие алгоритмы.
+
+// lockedSource — источник, безопасный для одновременного использования
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+var (
+	engineSource = &lockedSource{src: rand.NewSource(time.Now().UnixNano()).(rand.Source64)}
+	rng          = rand.New(engineSource)
+)
+
+// Seed задаёт начальное значение генератора движка
+func Seed(seed int64) {
+	engineSource.Seed(seed)
+}
+
+// reseed выбирает новое начальное значение из текущей последовательности генератора,
+// пересевает им генератор и возвращает его: последовательность меняется, но запуск,
+// начатый с известного seed, остаётся воспроизводимым
+func reseed() int64 {
+	seed := rng.Int63()
+	Seed(seed)
+	return seed
+}
@@ -0,0 +1,357 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ------------------------ Смысл вероятности мутации ------------------------ //
+
+// RateScope определяет смысл аргумента rate стратегии мутации
+type RateScope int
+
+const (
+	// RatePerGene — вероятность переворота каждого гена
+	RatePerGene RateScope = iota
+	// RatePerApplication — вероятность применения оператора к особи целиком
+	RatePerApplication
+)
+
+func (s RateScope) String() string {
+	switch s {
+	case RatePerGene:
+		return "PerGene"
+	case RatePerApplication:
+		return "PerApplication"
+	default:
+		return "Unknown"
+	}
+}
+
+// ScopedMutationStrategy — стратегия мутации, rate которой означает не вероятность на ген
+type ScopedMutationStrategy interface {
+	MutationStrategy
+	RateScope() RateScope
+}
+
+// MutationRateScope возвращает смысл rate стратегии (по умолчанию — вероятность на ген)
+func MutationRateScope(m MutationStrategy) RateScope {
+	if s, ok := m.(ScopedMutationStrategy); ok {
+		return s.RateScope()
+	}
+	return RatePerGene
+}
+
+// applicationRate переводит вероятность на ген в вероятность хотя бы одного изменения
+// в геноме длины n — так операторы, применяемые к особи целиком, получают сопоставимую частоту
+func applicationRate(perGene float64, n int) float64 {
+	return 1 - math.Pow(1-perGene, float64(n))
+}
+
+// rateBounds возвращает допустимый диапазон адаптируемой вероятности
+func rateBounds(scope RateScope, genomeLength int) (lo, hi float64) {
+	if scope == RatePerApplication {
+		return 0.01, 1
+	}
+	lo = 0.001
+	if genomeLength > 0 {
+		lo = 1 / float64(genomeLength)
+	}
+	return math.Min(lo, 0.5), 0.5
+}
+
+// ------------------------ Расписания ------------------------ //
+
+// RateSchedule определяет закон изменения вероятности по поколениям
+type RateSchedule int
+
+const (
+	ScheduleConstant    RateSchedule = iota // Вероятность не меняется
+	ScheduleLinear                          // Линейно от начального значения к конечному
+	ScheduleExponential                     // Геометрически от начального значения к конечному
+	ScheduleCosine                          // Косинусный отжиг от начального значения к конечному
+	ScheduleStep                            // Умножение на StepFactor каждые StepEvery поколений
+)
+
+func (s RateSchedule) String() string {
+	switch s {
+	case ScheduleConstant:
+		return "Constant"
+	case ScheduleLinear:
+		return "Linear"
+	case ScheduleExponential:
+		return "Exponential"
+	case ScheduleCosine:
+		return "Cosine"
+	case ScheduleStep:
+		return "Step"
+	default:
+		return "Unknown"
+	}
+}
+
+// RateAdaptation определяет способ адаптации вероятности мутации по ходу поиска
+type RateAdaptation int
+
+const (
+	AdaptNone     RateAdaptation = iota // Без адаптации
+	AdaptOneFifth                       // Правило 1/5 успеха Рехенберга
+	AdaptSelf                           // Самоадаптация: вероятность хранится в каждой особи
+)
+
+func (a RateAdaptation) String() string {
+	switch a {
+	case AdaptNone:
+		return "None"
+	case AdaptOneFifth:
+		return "OneFifth"
+	case AdaptSelf:
+		return "SelfAdaptive"
+	default:
+		return "Unknown"
+	}
+}
+
+// RateControlConfig задаёт управление вероятностями мутации и кроссовера.
+// Начальные значения — Config.MutationRate и CrossoverRate; нулевая конфигурация
+// сохраняет их постоянными. Острова с собственной MutationRate не управляются.
+type RateControlConfig struct {
+	MutationSchedule   RateSchedule
+	FinalMutationRate  float64 // Конечное значение расписания мутации (0 — 10% начального)
+	CrossoverSchedule  RateSchedule
+	FinalCrossoverRate float64 // Конечное значение расписания кроссовера (0 — 10% начального)
+	StepEvery          int     // Период ScheduleStep в поколениях (0 — десятая часть поколений)
+	StepFactor         float64 // Множитель ScheduleStep (0 — 0.5)
+
+	Adaptation     RateAdaptation // Адаптация мутации (только с постоянным расписанием)
+	OneFifthWindow int            // Поколений между корректировками правила 1/5 (0 — 1)
+	OneFifthFactor float64        // Множитель уменьшения по правилу 1/5 (0 — 0.85)
+	SelfAdaptTau   float64        // Скорость самоадаптации (0 — 1/√длины генома)
+}
+
+// Enabled сообщает, меняются ли вероятности по ходу эволюции
+func (c RateControlConfig) Enabled() bool {
+	return c.MutationSchedule != ScheduleConstant || c.CrossoverSchedule != ScheduleConstant || c.Adaptation != AdaptNone
+}
+
+// usesVariationRates сообщает, применяет ли модель мутацию и кроссовер ГА с вероятностями
+// MutationRate и CrossoverRate. EDA сэмплирует распределение, муравьи строят решения по феромону,
+// отжиг и поиск с запретами переходят к соседям — вероятности ГА они не читают
+func (m EvolutionModel) usesVariationRates() bool {
+	switch m {
+	case EDA, AntColony, SimulatedAnnealing, TabuSearch:
+		return false
+	default:
+		return true
+	}
+}
+
+// Validate проверяет согласованность настроек
+func (c RateControlConfig) Validate() error {
+	if c.FinalMutationRate < 0 || c.FinalMutationRate > 1 || c.FinalCrossoverRate < 0 || c.FinalCrossoverRate > 1 {
+		return errors.New("final rates of schedules must be in [0, 1]")
+	}
+	if c.StepEvery < 0 {
+		return fmt.Errorf("step schedule period must not be negative, got %d", c.StepEvery)
+	}
+	if c.StepFactor < 0 {
+		return fmt.Errorf("step schedule factor must not be negative, got %v", c.StepFactor)
+	}
+	if c.Adaptation != AdaptNone && c.MutationSchedule != ScheduleConstant {
+		return fmt.Errorf("mutation rate cannot follow the %v schedule and %v adaptation at the same time",
+			c.MutationSchedule, c.Adaptation)
+	}
+	if c.OneFifthWindow < 0 {
+		return fmt.Errorf("1/5 rule window must not be negative, got %d", c.OneFifthWindow)
+	}
+	if c.OneFifthFactor < 0 || c.OneFifthFactor >= 1 {
+		return fmt.Errorf("1/5 rule factor must be in (0, 1), got %v", c.OneFifthFactor)
+	}
+	if c.SelfAdaptTau < 0 {
+		return fmt.Errorf("self-adaptation rate must not be negative, got %v", c.SelfAdaptTau)
+	}
+	return nil
+}
+
+// scheduled возвращает значение расписания в поколении gen из total
+func (c RateControlConfig) scheduled(schedule RateSchedule, start, final float64, gen, total int) float64 {
+	if final == 0 {
+		final = start / 10
+	}
+	t := 1.0
+	if total > 0 {
+		t = math.Min(float64(gen)/float64(total), 1)
+	}
+	switch schedule {
+	case ScheduleLinear:
+		return start + (final-start)*t
+	case ScheduleExponential:
+		if start <= 0 || final <= 0 {
+			return start + (final-start)*t
+		}
+		return start * math.Pow(final/start, t)
+	case ScheduleCosine:
+		return final + (start-final)*(1+math.Cos(math.Pi*t))/2
+	case ScheduleStep:
+		every, factor := c.StepEvery, c.StepFactor
+		if every == 0 {
+			every = max(total/10, 1)
+		}
+		if factor == 0 {
+			factor = 0.5
+		}
+		return math.Min(start*math.Pow(factor, float64(gen/every)), 1)
+	default:
+		return start
+	}
+}
+
+// RatePoint — вероятности мутации и кроссовера после поколения
+type RatePoint struct {
+	Generation    int
+	MutationRate  float64 // Для самоадаптации — средняя вероятность особей популяции
+	CrossoverRate float64
+	SuccessRate   float64 // Доля потомков лучше родителей за поколение
+}
+
+// rateController хранит состояние управления вероятностями
+type rateController struct {
+	cfg           RateControlConfig
+	baseMutation  float64
+	baseCrossover float64
+
+	parentFitness int // Лучший фитнес родителей последнего мутированного потомка
+	pending       bool
+	trials        int // Потомков с последней корректировки правила 1/5
+	successes     int
+	generation    struct{ trials, successes int }
+
+	history []RatePoint
+}
+
+func newRateController(cfg RateControlConfig, mutationRate, crossoverRate float64) *rateController {
+	return &rateController{cfg: cfg, baseMutation: mutationRate, baseCrossover: crossoverRate}
+}
+
+// tau возвращает скорость самоадаптации для генома длины n
+func (rc *rateController) tau(n int) float64 {
+	if rc.cfg.SelfAdaptTau > 0 {
+		return rc.cfg.SelfAdaptTau
+	}
+	return 1 / math.Sqrt(math.Max(float64(n), 1))
+}
+
+// mutate применяет мутацию алгоритма к потомку parents с текущей вероятностью
+func (ga *Algorithm) mutate(child *Chromosome, parents ...Chromosome) {
+	ga.mutateWith(ga.MutationStrategy, ga.MutationRate, child, parents...)
+}
+
+// mutateWith применяет strategy с вероятностью rate. При самоадаптации потомок наследует
+// среднюю вероятность родителей, изменённую логнормальным шумом, и мутирует с ней.
+func (ga *Algorithm) mutateWith(strategy MutationStrategy, rate float64, child *Chromosome, parents ...Chromosome) {
+	rc := ga.rates
+	if rc != nil && len(parents) > 0 {
+		rc.parentFitness, rc.pending = parents[0].Fitness, true
+		inherited := 0.0
+		for _, p := range parents {
+			rc.parentFitness = max(rc.parentFitness, p.Fitness)
+			if p.MutationRate > 0 {
+				inherited += p.MutationRate
+			} else {
+				inherited += rate
+			}
+		}
+		if rc.cfg.Adaptation == AdaptSelf {
+			lo, hi := rateBounds(MutationRateScope(strategy), len(child.Genes))
+			inherited /= float64(len(parents))
+			rate = math.Min(math.Max(inherited*math.Exp(rc.tau(len(child.Genes))*rng.NormFloat64()), lo), hi)
+			child.MutationRate = rate
+		}
+	}
+	strategy.Mutate(child, rate, ga.Graph)
+}
+
+// observeOffspring учитывает успех оценённого потомка для правила 1/5 и журнала вероятностей
+func (ga *Algorithm) observeOffspring(child Chromosome) {
+	rc := ga.rates
+	if rc == nil || !rc.pending {
+		return
+	}
+	rc.pending = false
+	rc.trials++
+	rc.generation.trials++
+	if child.Fitness > rc.parentFitness {
+		rc.successes++
+		rc.generation.successes++
+	}
+}
+
+// adaptRates пересчитывает вероятности после поколения и добавляет точку в журнал.
+// Вызывается моделями эволюции перед увеличением CurrentGeneration.
+func (ga *Algorithm) adaptRates() {
+	rc := ga.rates
+	if rc == nil {
+		return
+	}
+	cfg := rc.cfg
+	gen := ga.CurrentGeneration + 1
+
+	if cfg.MutationSchedule != ScheduleConstant {
+		ga.MutationRate = math.Min(cfg.scheduled(cfg.MutationSchedule, rc.baseMutation, cfg.FinalMutationRate, gen, ga.Generations), 1)
+	}
+	if cfg.CrossoverSchedule != ScheduleConstant {
+		ga.CrossoverRate = math.Min(cfg.scheduled(cfg.CrossoverSchedule, rc.baseCrossover, cfg.FinalCrossoverRate, gen, ga.Generations), 1)
+		if ga.CrossoverStrategy != nil {
+			ga.CrossoverStrategy = ga.CrossoverStrategy.WithRate(ga.CrossoverRate)
+		}
+	}
+
+	switch cfg.Adaptation {
+	case AdaptOneFifth:
+		window, factor := max(cfg.OneFifthWindow, 1), cfg.OneFifthFactor
+		if factor == 0 {
+			factor = 0.85
+		}
+		if gen%window == 0 && rc.trials > 0 {
+			// Успешных потомков больше 1/5 — поиск можно расширить, меньше — сузить
+			lo, hi := rateBounds(MutationRateScope(ga.MutationStrategy), ga.Problem.GenomeLength())
+			ratio := float64(rc.successes) / float64(rc.trials)
+			if ratio > 0.2 {
+				ga.MutationRate /= factor
+			} else if ratio < 0.2 {
+				ga.MutationRate *= factor
+			}
+			ga.MutationRate = math.Min(math.Max(ga.MutationRate, lo), hi)
+			rc.trials, rc.successes = 0, 0
+		}
+	case AdaptSelf:
+		// Особям без собственной вероятности достаётся среднее по популяции
+		sum, n := 0.0, 0
+		for _, c := range ga.Population {
+			if c.MutationRate > 0 {
+				sum += c.MutationRate
+				n++
+			}
+		}
+		if n > 0 {
+			ga.MutationRate = sum / float64(n)
+		}
+	}
+
+	point := RatePoint{Generation: gen, MutationRate: ga.MutationRate, CrossoverRate: ga.CrossoverRate}
+	if rc.generation.trials > 0 {
+		point.SuccessRate = float64(rc.generation.successes) / float64(rc.generation.trials)
+	}
+	rc.generation.trials, rc.generation.successes = 0, 0
+	rc.history = append(rc.history, point)
+	ga.Logger.LogRates(point)
+}
+
+// RateHistory возвращает журнал вероятностей по поколениям (nil, если управление выключено)
+func (ga *Algorithm) RateHistory() []RatePoint {
+	if ga.rates == nil {
+		return nil
+	}
+	return ga.rates.history
+}
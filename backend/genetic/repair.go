@@ -0,0 +1,145 @@
+package genetic
+
+import (
+	"sort"
+)
+
+// RepairStrategy приводит хромосому к допустимому паросочетанию.
+// Приспособленность не пересчитывается.
+type RepairStrategy interface {
+	Repair(chrom *Chromosome, graph *Graph)
+	GetName() string
+}
+
+// RepairStrategy возвращает стратегию починки, выбранную конфигурацией:
+// явно заданную Repair или, если она не задана, починку по индексам при UseFastRepair
+// и в случайном порядке иначе
+func (c Config) RepairStrategy() RepairStrategy {
+	if c.Repair != nil {
+		return c.Repair
+	}
+	if c.UseFastRepair {
+		return &IndexOrderRepair{}
+	}
+	return &RandomOrderRepair{}
+}
+
+// repair чинит потомка стратегией из ModelConfig.Repair (по умолчанию — по индексам);
+// для задач, отличных от паросочетания, — починкой задачи
+func (ga *Algorithm) repair(chrom *Chromosome) {
+	if ga.Problem.Type() != Matching {
+		ga.Problem.Repair(chrom)
+		return
+	}
+	if ga.ModelConfig.Repair == nil {
+		RepairFast(chrom, ga.Graph)
+		return
+	}
+	ga.ModelConfig.Repair.Repair(chrom, ga.Graph)
+}
+
+// ------------------------ По индексам ------------------------ //
+
+// IndexOrderRepair оставляет в конфликте ребро с меньшим индексом (RepairFast)
+type IndexOrderRepair struct{}
+
+func (r *IndexOrderRepair) Repair(chrom *Chromosome, graph *Graph) {
+	RepairFast(chrom, graph)
+}
+
+func (r *IndexOrderRepair) GetName() string {
+	return "IndexOrder"
+}
+
+// ---------------------- Случайный порядок ---------------------- //
+
+// RandomOrderRepair обходит рёбра в случайном порядке (Repair)
+type RandomOrderRepair struct{}
+
+func (r *RandomOrderRepair) Repair(chrom *Chromosome, graph *Graph) {
+	Repair(chrom, graph)
+}
+
+func (r *RandomOrderRepair) GetName() string {
+	return "RandomOrder"
+}
+
+// ---------------------- С учётом степеней ---------------------- //
+
+// DegreeAwareRepair оставляет в конфликте ребро, у концов которого меньше всего
+// альтернатив: рёбра обходятся по возрастанию суммы степеней концов,
+// равные суммы — в случайном порядке
+type DegreeAwareRepair struct{}
+
+func (r *DegreeAwareRepair) Repair(chrom *Chromosome, graph *Graph) {
+	degree := make([]int, graph.NumVertices)
+	var selected []int
+	for i, e := range graph.Edges {
+		degree[e.U]++
+		degree[e.V]++
+		if chrom.Genes[i] {
+			selected = append(selected, i)
+		}
+	}
+	rng.Shuffle(len(selected), func(i, j int) {
+		selected[i], selected[j] = selected[j], selected[i]
+	})
+	cost := func(idx int) int {
+		e := graph.Edges[idx]
+		return degree[e.U] + degree[e.V]
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return cost(selected[i]) < cost(selected[j])
+	})
+
+	load := newVertexLoad(graph)
+	for _, idx := range selected {
+		e := graph.Edges[idx]
+		if !load.fits(e) {
+			chrom.Genes[idx] = false
+			continue
+		}
+		load.add(e)
+	}
+}
+
+func (r *DegreeAwareRepair) GetName() string {
+	return "DegreeAware"
+}
+
+// ------------------- Починка с достройкой ------------------- //
+
+// GreedyExtendRepair чинит хромосому стратегией Base, затем добавляет в случайном порядке
+// все рёбра, концы которых имеют свободную ёмкость, получая максимальное по включению паросочетание
+type GreedyExtendRepair struct {
+	Base RepairStrategy // Базовая починка (nil — по индексам)
+}
+
+func (r *GreedyExtendRepair) Repair(chrom *Chromosome, graph *Graph) {
+	if r.Base != nil {
+		r.Base.Repair(chrom, graph)
+	} else {
+		RepairFast(chrom, graph)
+	}
+
+	load := newVertexLoad(graph)
+	for i, on := range chrom.Genes {
+		if on {
+			load.add(graph.Edges[i])
+		}
+	}
+	for _, idx := range rng.Perm(len(graph.Edges)) {
+		e := graph.Edges[idx]
+		if e.U != e.V && !chrom.Genes[idx] && load.fits(e) {
+			chrom.Genes[idx] = true
+			load.add(e)
+		}
+	}
+}
+
+func (r *GreedyExtendRepair) GetName() string {
+	if r.Base != nil {
+		return r.Base.GetName() + "+GreedyExtend"
+	}
+	return "GreedyExtend"
+}
@@ -0,0 +1,242 @@
+package genetic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ------------------------ Реакция на стагнацию ------------------------ //
+
+// StagnationResponse определяет, что делать, когда лучшее решение перестаёт улучшаться
+type StagnationResponse int
+
+const (
+	RestartNone          StagnationResponse = iota // Без реакции
+	RestartPartial                                 // Сохранить элиту, остальные особи создать заново
+	RestartHypermutation                           // Повысить вероятность мутации на несколько поколений
+	RestartImmigrants                              // Заменить худшую часть популяции случайными особями
+	RestartFull                                    // Пересеять генератор и создать всю популяцию заново, сохранив bestSoFar
+)
+
+func (r StagnationResponse) String() string {
+	switch r {
+	case RestartNone:
+		return "None"
+	case RestartPartial:
+		return "PartialRestart"
+	case RestartHypermutation:
+		return "Hypermutation"
+	case RestartImmigrants:
+		return "RandomImmigrants"
+	case RestartFull:
+		return "FullRestart"
+	default:
+		return "Unknown"
+	}
+}
+
+// StagnationConfig задаёт обнаружение стагнации и реакцию на неё.
+// Стагнация — Window поколений подряд без улучшения bestSoFar (по BestSoFarEdges,
+// для взвешенного графа и других задач — по приспособленности).
+type StagnationConfig struct {
+	Window   int // Поколений без улучшения до срабатывания (0 — обнаружение выключено)
+	Response StagnationResponse
+
+	KeepElites               int     // Сохраняемых особей при частичном перезапуске (0 — размер элиты)
+	HypermutationRate        float64 // Вероятность мутации гипермутации (0 — в 10 раз выше текущей)
+	HypermutationGenerations int     // Длительность гипермутации в поколениях (0 — Window)
+	ImmigrantFraction        float64 // Доля популяции, заменяемая иммигрантами (0 — 0.2)
+	MaxRestarts              int     // Наибольшее число срабатываний (0 — без ограничения)
+}
+
+// Enabled сообщает, включена ли реакция на стагнацию
+func (c StagnationConfig) Enabled() bool {
+	return c.Window > 0 && c.Response != RestartNone
+}
+
+// Validate проверяет настройки для популяции размера populationSize
+func (c StagnationConfig) Validate(populationSize int) error {
+	if c.Window < 0 {
+		return fmt.Errorf("stagnation window must not be negative, got %d", c.Window)
+	}
+	if c.KeepElites < 0 || c.KeepElites >= populationSize {
+		return fmt.Errorf("restart must keep from 0 to %d elites, got %d", populationSize-1, c.KeepElites)
+	}
+	if c.HypermutationRate < 0 || c.HypermutationRate > 1 {
+		return fmt.Errorf("hypermutation rate must be in [0, 1], got %v", c.HypermutationRate)
+	}
+	if c.HypermutationGenerations < 0 {
+		return fmt.Errorf("hypermutation length must not be negative, got %d", c.HypermutationGenerations)
+	}
+	if c.ImmigrantFraction < 0 || c.ImmigrantFraction > 1 {
+		return fmt.Errorf("immigrant fraction must be in [0, 1], got %v", c.ImmigrantFraction)
+	}
+	if c.MaxRestarts < 0 {
+		return fmt.Errorf("restart limit must not be negative, got %d", c.MaxRestarts)
+	}
+	return nil
+}
+
+// RestartEvent — срабатывание реакции на стагнацию
+type RestartEvent struct {
+	Generation  int // Поколение, после которого сработала реакция
+	Response    StagnationResponse
+	Stagnated   int   // Поколений без улучшения
+	BestFitness int   // Приспособленность bestSoFar в момент срабатывания
+	BestEdges   int   // BestSoFarEdges в момент срабатывания
+	Replaced    int   // Заменённых особей (0 для гипермутации)
+	Seed        int64 // Новое начальное значение генератора (только для полного перезапуска)
+}
+
+// stagnationMonitor хранит состояние обнаружения стагнации
+type stagnationMonitor struct {
+	cfg         StagnationConfig
+	bestEdges   int
+	bestFitness int
+	since       int // Поколений без улучшения
+
+	hyperLeft int     // Оставшиеся поколения гипермутации
+	savedRate float64 // Вероятность мутации до гипермутации
+
+	events []RestartEvent
+}
+
+// checkStagnation обновляет bestSoFar лучшей особью популяции и, если он не улучшался
+// Window поколений, применяет реакцию. Вызывается моделями эволюции после adaptRates.
+func (ga *Algorithm) checkStagnation() {
+	sm := ga.stagnation
+	if sm == nil || len(ga.Population) == 0 {
+		return
+	}
+	ga.SetBestSoFar(ga.GetBestChromosome())
+
+	if sm.hyperLeft > 0 {
+		sm.hyperLeft--
+		if sm.hyperLeft == 0 {
+			ga.MutationRate = sm.savedRate
+			ga.Logger.LogInfo("Гипермутация завершена: вероятность мутации=%.4f", ga.MutationRate)
+		} else {
+			// Расписание вероятностей могло изменить MutationRate в adaptRates
+			ga.MutationRate = ga.hypermutationRate(sm.savedRate)
+		}
+	}
+
+	if ga.BestSoFarEdges > sm.bestEdges || ga.bestSoFar.Fitness > sm.bestFitness {
+		sm.bestEdges, sm.bestFitness = ga.BestSoFarEdges, ga.bestSoFar.Fitness
+		sm.since = 0
+		return
+	}
+	sm.since++
+	if sm.since < sm.cfg.Window || sm.hyperLeft > 0 {
+		return
+	}
+	if sm.cfg.MaxRestarts > 0 && len(sm.events) >= sm.cfg.MaxRestarts {
+		return
+	}
+
+	event := RestartEvent{
+		Generation:  ga.CurrentGeneration + 1,
+		Response:    sm.cfg.Response,
+		Stagnated:   sm.since,
+		BestFitness: ga.bestSoFar.Fitness,
+		BestEdges:   ga.BestSoFarEdges,
+	}
+	switch sm.cfg.Response {
+	case RestartPartial:
+		keep := sm.cfg.KeepElites
+		if keep == 0 {
+			keep = ga.SelectionStrategy.EliteSize
+		}
+		event.Replaced = ga.reinitializeWorst(len(ga.Population) - keep)
+	case RestartHypermutation:
+		length := sm.cfg.HypermutationGenerations
+		if length == 0 {
+			length = sm.cfg.Window
+		}
+		sm.savedRate = ga.MutationRate
+		sm.hyperLeft = length
+		ga.MutationRate = ga.hypermutationRate(sm.savedRate)
+	case RestartImmigrants:
+		fraction := sm.cfg.ImmigrantFraction
+		if fraction == 0 {
+			fraction = 0.2
+		}
+		event.Replaced = ga.reinitializeWorst(int(math.Ceil(fraction * float64(len(ga.Population)))))
+	case RestartFull:
+		// bestSoFar хранится отдельно от популяции и переживает перезапуск
+		event.Seed = reseed()
+		event.Replaced = ga.reinitializeAll()
+	}
+	if event.Replaced > 0 {
+		if len(ga.Islands) > 0 {
+			ga.ResetIslands()
+		}
+		ga.SetLocalBest(ga.GetBestChromosome())
+	}
+
+	sm.since = 0
+	sm.events = append(sm.events, event)
+	ga.Logger.LogRestart(event, ga.MutationRate)
+}
+
+// hypermutationRate возвращает вероятность мутации на время гипермутации
+func (ga *Algorithm) hypermutationRate(base float64) float64 {
+	if r := ga.stagnation.cfg.HypermutationRate; r > 0 {
+		return r
+	}
+	_, hi := rateBounds(MutationRateScope(ga.MutationStrategy), ga.Problem.GenomeLength())
+	return math.Min(10*base, hi)
+}
+
+// slotResetter реализуется моделями, состояние которых зависит от особей популяции
+// (возраст особей модели с постоянным состоянием, распределение EDA, феромон муравьёв)
+type slotResetter interface {
+	// resetSlots сообщает, что особи на позициях slots созданы заново; ga.Population уже обновлена
+	resetSlots(ga *Algorithm, slots []int)
+}
+
+// reinitializeSlots заменяет особей на позициях slots новыми, не переставляя остальных:
+// в клеточной модели позиция — клетка решётки, в модели с постоянным состоянием с ней связан возраст
+func (ga *Algorithm) reinitializeSlots(slots []int) {
+	for _, i := range slots {
+		ga.Population[i] = ga.GenerateChromosome()
+	}
+	if r, ok := ga.EvolutionModel.(slotResetter); ok {
+		r.resetSlots(ga, slots)
+	}
+}
+
+// reinitializeWorst заменяет n худших особей популяции новыми и возвращает число заменённых
+func (ga *Algorithm) reinitializeWorst(n int) int {
+	n = min(max(n, 0), len(ga.Population))
+	order := make([]int, len(ga.Population))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ga.Population[order[a]].Fitness < ga.Population[order[b]].Fitness
+	})
+	ga.reinitializeSlots(order[:n])
+	return n
+}
+
+// reinitializeAll создаёт всю популяцию заново и возвращает её размер. В отличие от
+// InitializePopulation затравочные паросочетания не внедряются: они вернули бы поиск
+// в исчерпанную область
+func (ga *Algorithm) reinitializeAll() int {
+	slots := make([]int, len(ga.Population))
+	for i := range slots {
+		slots[i] = i
+	}
+	ga.reinitializeSlots(slots)
+	return len(slots)
+}
+
+// RestartEvents возвращает срабатывания реакции на стагнацию (nil, если она выключена)
+func (ga *Algorithm) RestartEvents() []RestartEvent {
+	if ga.stagnation == nil {
+		return nil
+	}
+	return ga.stagnation.events
+}
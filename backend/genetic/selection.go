@@ -1,7 +1,7 @@
 package genetic
 
 import (
-	"math/rand"
+	"math"
 	"sort"
 )
 
@@ -24,9 +24,9 @@ func (t *TournamentSelectionStrategy) Select(population []Chromosome) Chromosome
 		t.TournamentSize = 3
 	}
 
-	best := population[rand.Intn(len(population))]
+	best := population[rng.Intn(len(population))]
 	for i := 1; i < t.TournamentSize; i++ {
-		contender := population[rand.Intn(len(population))]
+		contender := population[rng.Intn(len(population))]
 		if contender.Fitness > best.Fitness {
 			best = contender
 		}
@@ -54,10 +54,10 @@ func (r *RouletteWheelSelectionStrategy) Select(population []Chromosome) Chromos
 
 	if totalFitness == 0 {
 		// Возвращаем случайную хромосому
-		return population[rand.Intn(len(population))]
+		return population[rng.Intn(len(population))]
 	}
 
-	randValue := rand.Intn(totalFitness)
+	randValue := rng.Intn(totalFitness)
 	cumulative := 0
 	for _, c := range population {
 		cumulative += c.Fitness
@@ -77,37 +77,440 @@ func (r *RouletteWheelSelectionStrategy) GetName() string {
 type RankSelectionStrategy struct{}
 
 func (r *RankSelectionStrategy) Select(population []Chromosome) Chromosome {
+	return r.SelectMany(population, 1)[0]
+}
+
+// SelectMany сортирует популяцию один раз и выбирает n особей
+func (r *RankSelectionStrategy) SelectMany(population []Chromosome, n int) []Chromosome {
+	if len(population) == 0 {
+		panic("empty population")
+	}
+
+	sorted := sortedByFitness(population)
+
+	// Линейное ранжирование: вероятность выбора пропорциональна рангу
+	weights := make([]float64, len(sorted))
+	for i := range weights {
+		weights[i] = float64(len(sorted) - i)
+	}
+	return sampleByWeights(sorted, weights, n)
+}
+
+func (r *RankSelectionStrategy) GetName() string {
+	return "Rank"
+}
+
+// --------------------- Линейное ранжирование --------------------- //
+
+// LinearRankingSelectionStrategy — линейное ранжирование с давлением отбора Pressure ∈ [1, 2].
+// Pressure = 1 даёт равномерный отбор, Pressure = 2 — максимальное давление.
+type LinearRankingSelectionStrategy struct {
+	Pressure float64
+}
+
+func (r *LinearRankingSelectionStrategy) Select(population []Chromosome) Chromosome {
+	return r.SelectMany(population, 1)[0]
+}
+
+func (r *LinearRankingSelectionStrategy) SelectMany(population []Chromosome, n int) []Chromosome {
+	if len(population) == 0 {
+		panic("empty population")
+	}
+	if r.Pressure < 1 || r.Pressure > 2 {
+		r.Pressure = 1.5
+	}
+
+	sorted := sortedByFitness(population)
+	size := float64(len(sorted))
+	weights := make([]float64, len(sorted))
+	for i := range weights {
+		// i = 0 — лучшая особь; pos — позиция от худшей
+		pos := size - 1 - float64(i)
+		if size == 1 {
+			weights[i] = 1
+			continue
+		}
+		weights[i] = (2-r.Pressure)/size + 2*pos*(r.Pressure-1)/(size*(size-1))
+	}
+	return sampleByWeights(sorted, weights, n)
+}
+
+func (r *LinearRankingSelectionStrategy) GetName() string {
+	return "LinearRank"
+}
+
+// ------------------- Экспоненциальное ранжирование ------------------- //
+
+// ExponentialRankingSelectionStrategy — вес особи ранга i равен Base^i (ранг 0 — лучшая).
+// Чем меньше Base ∈ (0, 1), тем выше давление отбора.
+type ExponentialRankingSelectionStrategy struct {
+	Base float64
+}
+
+func (r *ExponentialRankingSelectionStrategy) Select(population []Chromosome) Chromosome {
+	return r.SelectMany(population, 1)[0]
+}
+
+func (r *ExponentialRankingSelectionStrategy) SelectMany(population []Chromosome, n int) []Chromosome {
+	if len(population) == 0 {
+		panic("empty population")
+	}
+	if r.Base <= 0 || r.Base >= 1 {
+		r.Base = 0.95
+	}
+
+	sorted := sortedByFitness(population)
+	weights := make([]float64, len(sorted))
+	w := 1.0
+	for i := range weights {
+		weights[i] = w
+		w *= r.Base
+	}
+	return sampleByWeights(sorted, weights, n)
+}
+
+func (r *ExponentialRankingSelectionStrategy) GetName() string {
+	return "ExponentialRank"
+}
+
+// ----------------- Стохастическая универсальная выборка ----------------- //
+
+// StochasticUniversalSamplingStrategy — пропорциональный отбор с n равноотстоящими указателями.
+// Даёт меньший разброс числа потомков, чем рулетка.
+type StochasticUniversalSamplingStrategy struct{}
+
+func (s *StochasticUniversalSamplingStrategy) Select(population []Chromosome) Chromosome {
+	return s.SelectMany(population, 1)[0]
+}
+
+func (s *StochasticUniversalSamplingStrategy) SelectMany(population []Chromosome, n int) []Chromosome {
+	if len(population) == 0 {
+		panic("empty population")
+	}
+
+	weights := make([]float64, len(population))
+	for i, c := range population {
+		weights[i] = float64(c.Fitness)
+	}
+	return sampleUniversal(population, weights, n)
+}
+
+func (s *StochasticUniversalSamplingStrategy) GetName() string {
+	return "SUS"
+}
+
+// ----------------------- Больцмановская селекция ----------------------- //
+
+// CoolingSchedule задаёт закон понижения температуры
+type CoolingSchedule int
+
+const (
+	LinearCooling CoolingSchedule = iota
+	ExponentialCooling
+)
+
+// BoltzmannSelectionStrategy — отбор с весами exp(f/T).
+// Температура понижается от InitialTemperature до FinalTemperature за ga.Generations поколений.
+type BoltzmannSelectionStrategy struct {
+	InitialTemperature float64
+	FinalTemperature   float64
+	Schedule           CoolingSchedule
+
+	temperature float64
+}
+
+// Prepare пересчитывает температуру для текущего поколения
+func (b *BoltzmannSelectionStrategy) Prepare(ga *Algorithm) {
+	b.temperature = b.Temperature(ga.CurrentGeneration, ga.Generations)
+}
+
+// Temperature возвращает температуру на поколении gen из total
+func (b *BoltzmannSelectionStrategy) Temperature(gen, total int) float64 {
+	t0, t1 := b.InitialTemperature, b.FinalTemperature
+	if t0 <= 0 {
+		t0 = 10
+	}
+	if t1 <= 0 || t1 > t0 {
+		t1 = 0.1
+	}
+	progress := 1.0
+	if total > 0 {
+		progress = math.Min(float64(gen)/float64(total), 1)
+	}
+	switch b.Schedule {
+	case ExponentialCooling:
+		return t0 * math.Pow(t1/t0, progress)
+	default:
+		return t0 + (t1-t0)*progress
+	}
+}
+
+func (b *BoltzmannSelectionStrategy) Select(population []Chromosome) Chromosome {
+	return b.SelectMany(population, 1)[0]
+}
+
+func (b *BoltzmannSelectionStrategy) SelectMany(population []Chromosome, n int) []Chromosome {
 	if len(population) == 0 {
 		panic("empty population")
 	}
+	t := b.temperature
+	if t <= 0 {
+		t = b.Temperature(0, 0)
+	}
 
+	maxFitness := population[0].Fitness
+	for _, c := range population {
+		if c.Fitness > maxFitness {
+			maxFitness = c.Fitness
+		}
+	}
+	// Сдвигаем на максимум, чтобы избежать переполнения exp
+	weights := make([]float64, len(population))
+	for i, c := range population {
+		weights[i] = math.Exp(float64(c.Fitness-maxFitness) / t)
+	}
+	return sampleByWeights(population, weights, n)
+}
+
+func (b *BoltzmannSelectionStrategy) GetName() string {
+	return "Boltzmann"
+}
+
+// ------------------------- Усечённая селекция ------------------------- //
+
+// TruncationSelectionStrategy выбирает равновероятно из доли Fraction лучших особей
+type TruncationSelectionStrategy struct {
+	Fraction float64
+}
+
+func (t *TruncationSelectionStrategy) Select(population []Chromosome) Chromosome {
+	return t.SelectMany(population, 1)[0]
+}
+
+func (t *TruncationSelectionStrategy) SelectMany(population []Chromosome, n int) []Chromosome {
+	if len(population) == 0 {
+		panic("empty population")
+	}
+	if t.Fraction <= 0 || t.Fraction > 1 {
+		t.Fraction = 0.5
+	}
+
+	sorted := sortedByFitness(population)
+	top := int(math.Ceil(t.Fraction * float64(len(sorted))))
+	if top < 1 {
+		top = 1
+	}
+	selected := make([]Chromosome, n)
+	for i := range selected {
+		selected[i] = sorted[rng.Intn(top)]
+	}
+	return selected
+}
+
+func (t *TruncationSelectionStrategy) GetName() string {
+	return "Truncation"
+}
+
+// ------------------------- Лексикографический отбор ------------------------- //
+
+// LexicaseSelectionStrategy — lexicase-отбор, где «тестовыми случаями» служат вершины графа:
+// результат особи на случае — число рёбер её допустимого паросочетания при вершине (не больше
+// ёмкости вершины; при единичных ёмкостях — покрыта ли вершина).
+// Случаи перебираются в случайном порядке, на каждом остаются только лучшие кандидаты.
+type LexicaseSelectionStrategy struct {
+	graph *Graph
+}
+
+// Prepare запоминает граф, по вершинам которого строятся случаи
+func (l *LexicaseSelectionStrategy) Prepare(ga *Algorithm) {
+	l.graph = ga.Graph
+}
+
+func (l *LexicaseSelectionStrategy) Select(population []Chromosome) Chromosome {
+	return l.SelectMany(population, 1)[0]
+}
+
+func (l *LexicaseSelectionStrategy) SelectMany(population []Chromosome, n int) []Chromosome {
+	if len(population) == 0 {
+		panic("empty population")
+	}
+	if l.graph == nil {
+		// Без графа случаев нет — равновероятный отбор
+		selected := make([]Chromosome, n)
+		for i := range selected {
+			selected[i] = population[rng.Intn(len(population))]
+		}
+		return selected
+	}
+
+	// Загрузку вершин считаем один раз на всю выборку
+	loads := make([][]int, len(population))
+	for i, c := range population {
+		loads[i] = matchedLoad(c, l.graph)
+	}
+
+	cases := make([]int, l.graph.NumVertices)
+	for i := range cases {
+		cases[i] = i
+	}
+	selected := make([]Chromosome, n)
+	for k := range selected {
+		rng.Shuffle(len(cases), func(i, j int) {
+			cases[i], cases[j] = cases[j], cases[i]
+		})
+		candidates := make([]int, len(population))
+		for i := range candidates {
+			candidates[i] = i
+		}
+		for _, v := range cases {
+			if len(candidates) == 1 {
+				break
+			}
+			best := 0
+			for _, idx := range candidates {
+				best = max(best, loads[idx][v])
+			}
+			passed := candidates[:0:0]
+			for _, idx := range candidates {
+				if loads[idx][v] == best {
+					passed = append(passed, idx)
+				}
+			}
+			candidates = passed
+		}
+		selected[k] = population[candidates[rng.Intn(len(candidates))]]
+	}
+	return selected
+}
+
+func (l *LexicaseSelectionStrategy) GetName() string {
+	return "Lexicase"
+}
+
+// matchedLoad возвращает число рёбер допустимой части решения хромосомы при каждой вершине;
+// рёбра берутся по возрастанию индекса, пока позволяют ёмкости, как в ValidMatchingEdges
+func matchedLoad(chrom Chromosome, graph *Graph) []int {
+	load := newVertexLoad(graph)
+	for i, gene := range chrom.Genes {
+		if gene && load.fits(graph.Edges[i]) {
+			load.add(graph.Edges[i])
+		}
+	}
+	return load.load
+}
+
+// ---------------------------- Утилиты ---------------------------- //
+
+// sortedByFitness возвращает копию популяции, отсортированную по убыванию фитнеса
+func sortedByFitness(population []Chromosome) []Chromosome {
 	sorted := make([]Chromosome, len(population))
 	copy(sorted, population)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Fitness > sorted[j].Fitness
 	})
+	return sorted
+}
 
-	// Линейное ранжирование: вероятность выбора пропорциональна рангу
-	ranks := make([]int, len(sorted))
-	for i := range ranks {
-		ranks[i] = len(sorted) - i
+// sampleByWeights выбирает n особей независимо, пропорционально неотрицательным весам
+func sampleByWeights(population []Chromosome, weights []float64, n int) []Chromosome {
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		if w > 0 {
+			total += w
+		}
+		cumulative[i] = total
 	}
 
-	total := len(sorted) * (len(sorted) + 1) / 2
-	randValue := rand.Intn(total)
+	selected := make([]Chromosome, n)
+	for k := range selected {
+		if total == 0 {
+			selected[k] = population[rng.Intn(len(population))]
+			continue
+		}
+		r := rng.Float64() * total
+		idx := sort.SearchFloat64s(cumulative, r)
+		for idx < len(cumulative)-1 && cumulative[idx] <= r {
+			idx++
+		}
+		selected[k] = population[idx]
+	}
+	return selected
+}
 
-	cumulative := 0
-	for i, rank := range ranks {
-		cumulative += rank
-		if cumulative >= randValue {
-			return sorted[i]
+// sampleUniversal выбирает n особей одним проходом колеса с n равноотстоящими указателями
+func sampleUniversal(population []Chromosome, weights []float64, n int) []Chromosome {
+	total := 0.0
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	selected := make([]Chromosome, 0, n)
+	if total == 0 || n <= 0 {
+		for len(selected) < n {
+			selected = append(selected, population[rng.Intn(len(population))])
 		}
+		return selected
 	}
-	return sorted[len(sorted)-1]
+
+	step := total / float64(n)
+	pointer := rng.Float64() * step
+	cumulative := 0.0
+	for i, w := range weights {
+		if w > 0 {
+			cumulative += w
+		}
+		for len(selected) < n && pointer < cumulative {
+			selected = append(selected, population[i])
+			pointer += step
+		}
+	}
+	// Погрешность округления может оставить последний указатель за колесом
+	for len(selected) < n {
+		selected = append(selected, population[len(population)-1])
+	}
+	rng.Shuffle(len(selected), func(i, j int) {
+		selected[i], selected[j] = selected[j], selected[i]
+	})
+	return selected
 }
 
-func (r *RankSelectionStrategy) GetName() string {
-	return "Rank"
+// selectParents выбирает n родителей: одним вызовом SelectMany, если стратегия это умеет,
+// иначе n вызовами Select
+// При включённом разделении фитнеса отбор идёт по фитнесу, поделённому на размер ниши,
+// а выбранным особям возвращается истинный фитнес.
+func (ga *Algorithm) selectParents(population []Chromosome, n int) []Chromosome {
+	return ga.selectParentsWith(ga.SelectionStrategy.Strategy, population, n)
+}
+
+// selectParentsWith — то же, что selectParents, но с явно заданной стратегией (например, острова)
+func (ga *Algorithm) selectParentsWith(strategy SelectionStrategy, population []Chromosome, n int) []Chromosome {
+	if aware, ok := strategy.(AlgorithmAwareSelection); ok {
+		aware.Prepare(ga)
+	}
+
+	var original map[string]int
+	if ga.ModelConfig.Diversity.SharingRadius > 0 {
+		population, original = ga.sharedPopulation(population)
+	}
+
+	var selected []Chromosome
+	if batch, ok := strategy.(BatchSelectionStrategy); ok {
+		selected = batch.SelectMany(population, n)
+	} else {
+		selected = make([]Chromosome, n)
+		for i := range selected {
+			selected[i] = strategy.Select(population)
+		}
+	}
+
+	if original != nil {
+		for i := range selected {
+			selected[i].Fitness = original[chromosomeKey(selected[i])]
+		}
+	}
+	return selected
 }
 
 // ---------------------------- Элитизм ---------------------------- //
@@ -121,5 +524,5 @@ func (ga *Algorithm) getElites() []Chromosome {
 }
 
 func (s *ElitismWrapper) GetName() string {
-	return "Elitism+" + s.Strategy.GetName()
+	return "Elitism+" + operatorName(s.Strategy)
 }
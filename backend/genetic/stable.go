@@ -0,0 +1,285 @@
+package genetic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Устойчивое паросочетание: на двудольном графе каждая вершина упорядочивает соседей
+// (Graph.Preferences). Соседи, которых нет в списке, неприемлемы, и ребро с ними не может
+// входить в решение. Пара (u, v) блокирующая, если обе вершины предпочитают друг друга
+// текущим партнёрам (или свободны).
+
+// StableMetrics — показатели паросочетания с предпочтениями
+type StableMetrics struct {
+	Size            int   // Число рёбер
+	BlockingPairs   int   // Число блокирующих пар
+	EgalitarianCost int   // Сумма рангов партнёров (1 — первый выбор); свободная вершина даёт длину списка + 1
+	RankProfile     []int // RankProfile[k] — число вершин, сочетающихся со своим (k+1)-м выбором
+}
+
+// CompareRankProfiles сравнивает профили рангов лексикографически: больше первых выборов,
+// затем вторых и т.д. Возвращает 1, если a лучше b, -1, если хуже, и 0 при равенстве.
+func CompareRankProfiles(a, b []int) int {
+	for k := 0; k < len(a) || k < len(b); k++ {
+		var x, y int
+		if k < len(a) {
+			x = a[k]
+		}
+		if k < len(b) {
+			y = b[k]
+		}
+		if x != y {
+			if x > y {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// StableSolution — паросочетание и его показатели
+type StableSolution struct {
+	Genes   []bool
+	Metrics StableMetrics
+}
+
+// acceptablePair — пара вершин, приемлемых друг для друга, и первое соединяющее их ребро
+type acceptablePair struct {
+	u, v, edge int
+}
+
+// validatePreferences проверяет, что списки предпочтений состоят из различных соседей вершины
+func validatePreferences(graph *Graph) error {
+	if graph.Preferences == nil {
+		return errors.New("stable matching requires vertex preference lists")
+	}
+	if len(graph.Preferences) != graph.NumVertices {
+		return fmt.Errorf("graph has %d preference lists for %d vertices", len(graph.Preferences), graph.NumVertices)
+	}
+	adjacent := make(map[[2]int]bool, 2*len(graph.Edges))
+	for _, e := range graph.Edges {
+		adjacent[[2]int{e.U, e.V}] = true
+		adjacent[[2]int{e.V, e.U}] = true
+	}
+	for v, list := range graph.Preferences {
+		seen := make(map[int]bool, len(list))
+		for _, w := range list {
+			if !adjacent[[2]int{v, w}] {
+				return fmt.Errorf("vertex %d lists %d, which is not its neighbour", v, w)
+			}
+			if seen[w] {
+				return fmt.Errorf("vertex %d lists %d more than once", v, w)
+			}
+			seen[w] = true
+		}
+	}
+	return nil
+}
+
+// ------------------------ Задача ------------------------ //
+
+// StableMatchingProblem — паросочетание по спискам предпочтений. Приспособленность
+// лексикографическая: меньше блокирующих пар, затем больше рёбер, затем меньше эгалитарная стоимость.
+type StableMatchingProblem struct {
+	graph     *Graph
+	rank      []map[int]int // rank[v][w] — позиция w в списке v (с 1)
+	pairs     []acceptablePair
+	proposers []int // Левая доля: предлагающая сторона алгоритма Гейла–Шепли
+	reference StableSolution
+
+	egalitarianMax int // Эгалитарная стоимость пустого паросочетания
+	sizeWeight     int
+	blockingWeight int
+}
+
+func newStableMatchingProblem(graph *Graph) (*StableMatchingProblem, error) {
+	if err := validatePreferences(graph); err != nil {
+		return nil, err
+	}
+	left, _, err := bipartition(graph)
+	if err != nil {
+		return nil, fmt.Errorf("stable matching: %w", err)
+	}
+
+	p := &StableMatchingProblem{
+		graph:     graph,
+		rank:      make([]map[int]int, graph.NumVertices),
+		proposers: left,
+	}
+	for v, list := range graph.Preferences {
+		p.rank[v] = make(map[int]int, len(list))
+		for k, w := range list {
+			p.rank[v][w] = k + 1
+		}
+		p.egalitarianMax += len(list) + 1
+	}
+	seen := make(map[[2]int]bool)
+	for i, e := range graph.Edges {
+		key := [2]int{min(e.U, e.V), max(e.U, e.V)}
+		if seen[key] || !p.acceptable(e.U, e.V) {
+			continue
+		}
+		seen[key] = true
+		p.pairs = append(p.pairs, acceptablePair{u: e.U, v: e.V, edge: i})
+	}
+
+	// Стоимость и размер ограничены, поэтому каждый уровень весит больше всех младших вместе
+	p.sizeWeight = p.egalitarianMax + 1
+	p.blockingWeight = (graph.NumVertices/2 + 1) * p.sizeWeight
+
+	genes := p.galeShapley()
+	p.reference = StableSolution{Genes: genes, Metrics: p.Metrics(genes)}
+	return p, nil
+}
+
+// acceptable сообщает, приемлемы ли u и v друг для друга
+func (p *StableMatchingProblem) acceptable(u, v int) bool {
+	_, ok1 := p.rank[u][v]
+	_, ok2 := p.rank[v][u]
+	return ok1 && ok2
+}
+
+// galeShapley строит устойчивое паросочетание, оптимальное для левой доли
+func (p *StableMatchingProblem) galeShapley() []bool {
+	partner := make([]int, p.graph.NumVertices)
+	next := make([]int, p.graph.NumVertices) // Следующая позиция в списке предлагающего
+	for v := range partner {
+		partner[v] = -1
+	}
+	free := append([]int(nil), p.proposers...)
+	for len(free) > 0 {
+		q := free[len(free)-1]
+		free = free[:len(free)-1]
+		for next[q] < len(p.graph.Preferences[q]) {
+			r := p.graph.Preferences[q][next[q]]
+			next[q]++
+			rq, ok := p.rank[r][q]
+			if !ok {
+				continue
+			}
+			if cur := partner[r]; cur < 0 || rq < p.rank[r][cur] {
+				if cur >= 0 {
+					partner[cur] = -1
+					free = append(free, cur)
+				}
+				partner[r], partner[q] = q, r
+				break
+			}
+		}
+	}
+
+	genes := make([]bool, len(p.graph.Edges))
+	for _, pair := range p.pairs {
+		if partner[pair.u] == pair.v {
+			genes[pair.edge] = true
+		}
+	}
+	return genes
+}
+
+// partners возвращает партнёра каждой вершины в допустимой части решения (-1 — свободна):
+// рёбра берутся по возрастанию индекса, неприемлемые рёбра пропускаются
+func (p *StableMatchingProblem) partners(genes []bool) ([]int, []bool) {
+	partner := make([]int, p.graph.NumVertices)
+	for v := range partner {
+		partner[v] = -1
+	}
+	kept := make([]bool, len(genes))
+	for i, on := range genes {
+		e := p.graph.Edges[i]
+		if !on || e.U == e.V || partner[e.U] >= 0 || partner[e.V] >= 0 || !p.acceptable(e.U, e.V) {
+			continue
+		}
+		partner[e.U], partner[e.V] = e.V, e.U
+		kept[i] = true
+	}
+	return partner, kept
+}
+
+// Metrics вычисляет показатели допустимой части решения
+func (p *StableMatchingProblem) Metrics(genes []bool) StableMetrics {
+	partner, kept := p.partners(genes)
+	m := StableMetrics{Size: countTrue(kept)}
+
+	// prefers сообщает, предпочитает ли x вершину y текущему партнёру
+	prefers := func(x, y int) bool {
+		return partner[x] < 0 || p.rank[x][y] < p.rank[x][partner[x]]
+	}
+	for _, pair := range p.pairs {
+		if partner[pair.u] != pair.v && prefers(pair.u, pair.v) && prefers(pair.v, pair.u) {
+			m.BlockingPairs++
+		}
+	}
+	for v, w := range partner {
+		if w < 0 {
+			m.EgalitarianCost += len(p.graph.Preferences[v]) + 1
+			continue
+		}
+		r := p.rank[v][w]
+		m.EgalitarianCost += r
+		for len(m.RankProfile) < r {
+			m.RankProfile = append(m.RankProfile, 0)
+		}
+		m.RankProfile[r-1]++
+	}
+	return m
+}
+
+func (p *StableMatchingProblem) Type() ProblemType { return StableMatching }
+func (p *StableMatchingProblem) GenomeLength() int { return len(p.graph.Edges) }
+
+func (p *StableMatchingProblem) Evaluate(chrom *Chromosome) {
+	m := p.Metrics(chrom.Genes)
+	chrom.Fitness = (len(p.pairs)-m.BlockingPairs)*p.blockingWeight + m.Size*p.sizeWeight +
+		p.egalitarianMax - m.EgalitarianCost
+}
+
+// Repair удаляет неприемлемые и конфликтующие рёбра (по возрастанию индекса)
+func (p *StableMatchingProblem) Repair(chrom *Chromosome) {
+	_, kept := p.partners(chrom.Genes)
+	copy(chrom.Genes, kept)
+}
+
+func (p *StableMatchingProblem) Feasible(genes []bool) bool {
+	_, kept := p.partners(genes)
+	return countTrue(kept) == countTrue(genes)
+}
+
+func (p *StableMatchingProblem) Objective(genes []bool) int {
+	_, kept := p.partners(genes)
+	return countTrue(kept)
+}
+
+// ReferenceOptimum неизвестен: паросочетание Гейла–Шепли устойчиво и наибольшее среди устойчивых,
+// но не обязательно имеет наименьшую эгалитарную стоимость
+func (p *StableMatchingProblem) ReferenceOptimum() (int, bool) {
+	return 0, false
+}
+
+func (p *StableMatchingProblem) DescribeSolution(genes []bool) string {
+	m := p.Metrics(genes)
+	return fmt.Sprintf("matching of %d edges, %d blocking pairs, egalitarian cost %d",
+		m.Size, m.BlockingPairs, m.EgalitarianCost)
+}
+
+// GaleShapley находит устойчивое паросочетание двудольного графа по спискам предпочтений;
+// предлагает доля, содержащая вершину 0
+func GaleShapley(graph *Graph) (StableSolution, error) {
+	p, err := newStableMatchingProblem(graph)
+	if err != nil {
+		return StableSolution{}, err
+	}
+	return p.reference, nil
+}
+
+// StableReference возвращает паросочетание Гейла–Шепли, вычисленное при создании алгоритма,
+// и признак того, что решается задача об устойчивом паросочетании
+func (ga *Algorithm) StableReference() (StableSolution, bool) {
+	p, ok := ga.Problem.(*StableMatchingProblem)
+	if !ok {
+		return StableSolution{}, false
+	}
+	return p.reference, true
+}
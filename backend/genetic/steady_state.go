@@ -0,0 +1,286 @@
+package genetic
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ------------------------ Настройки ------------------------ //
+
+// SteadyStateReplacement определяет, какую особь вытесняет потомок в модели с постоянным состоянием
+type SteadyStateReplacement int
+
+const (
+	// SteadyReplaceWorst — худшая особь, если потомок лучше неё
+	SteadyReplaceWorst SteadyStateReplacement = iota
+	// SteadyReplaceRandom — случайная особь
+	SteadyReplaceRandom
+	// SteadyReplaceOldest — особь, дольше всех находящаяся в популяции
+	SteadyReplaceOldest
+	// SteadyReplaceParent — худший из родителей, если потомок лучше него
+	SteadyReplaceParent
+	// SteadyReplaceCrowding — ближайшая по Хэммингу из CrowdingFactor случайных особей (кроудинг Де Йонга)
+	SteadyReplaceCrowding
+)
+
+func (r SteadyStateReplacement) String() string {
+	switch r {
+	case SteadyReplaceWorst:
+		return "Worst"
+	case SteadyReplaceRandom:
+		return "Random"
+	case SteadyReplaceOldest:
+		return "Oldest"
+	case SteadyReplaceParent:
+		return "Parent"
+	case SteadyReplaceCrowding:
+		return "Crowding"
+	default:
+		return "Unknown"
+	}
+}
+
+// SteadyStateConfig задаёт модель с постоянным состоянием. Поколение модели — эпоха
+// из BirthsPerGeneration рождений, по умолчанию PopulationSize, чтобы число поколений
+// и графики сходимости были сопоставимы с генерационными моделями.
+type SteadyStateConfig struct {
+	Offspring           int // Потомков за шаг: все создаются из одной популяции и вставляются вместе (0 — 1)
+	Replacement         SteadyStateReplacement
+	CrowdingFactor      int // Размер выборки для SteadyReplaceCrowding (0 — 3)
+	BirthsPerGeneration int // Рождений в поколении (0 — PopulationSize)
+}
+
+// Validate проверяет настройки для популяции размера populationSize
+func (c SteadyStateConfig) Validate(populationSize int) error {
+	if c.Offspring < 0 || c.Offspring > populationSize {
+		return fmt.Errorf("steady state offspring per step must be in [0, %d], got %d", populationSize, c.Offspring)
+	}
+	if c.CrowdingFactor < 0 {
+		return fmt.Errorf("crowding factor must not be negative, got %d", c.CrowdingFactor)
+	}
+	if c.BirthsPerGeneration < 0 {
+		return fmt.Errorf("births per generation must not be negative, got %d", c.BirthsPerGeneration)
+	}
+	return nil
+}
+
+// ------------------------ Куча индексов ------------------------ //
+
+// indexHeap — двоичная куча индексов популяции с порядком less; pos позволяет
+// восстановить порядок после замены особи за O(log n)
+type indexHeap struct {
+	items []int
+	pos   []int
+	less  func(a, b int) bool
+}
+
+func newIndexHeap(n int, less func(a, b int) bool) *indexHeap {
+	h := &indexHeap{items: make([]int, n), pos: make([]int, n), less: less}
+	for i := range h.items {
+		h.items[i], h.pos[i] = i, i
+	}
+	heap.Init(h)
+	return h
+}
+
+func (h *indexHeap) Len() int           { return len(h.items) }
+func (h *indexHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *indexHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.pos[h.items[i]], h.pos[h.items[j]] = i, j
+}
+func (h *indexHeap) Push(x any) { panic("indexHeap has fixed size") }
+func (h *indexHeap) Pop() any   { panic("indexHeap has fixed size") }
+
+// top возвращает индекс особи в вершине кучи
+func (h *indexHeap) top() int { return h.items[0] }
+
+// fix восстанавливает порядок после изменения особи idx
+func (h *indexHeap) fix(idx int) { heap.Fix(h, h.pos[idx]) }
+
+// ------------------------ Модель ------------------------ //
+
+// SteadyStateEvolutionModel реализует модель с постоянным состоянием
+// На каждом шаге создаёт Config.Offspring потомков и вставляет их в популяцию по политике замены
+// Подходит для задач, где важно сохранять хорошие решения
+type SteadyStateEvolutionModel struct {
+	Config SteadyStateConfig
+
+	birth  []int // Номер рождения особи на позиции i (для SteadyReplaceOldest)
+	clock  int
+	worst  *indexHeap
+	oldest *indexHeap
+	best   int // Индекс лучшей особи: политики без соревнования её не вытесняют
+}
+
+// prepare строит кучи для текущей популяции; популяция могла измениться между поколениями
+func (m *SteadyStateEvolutionModel) prepare(ga *Algorithm) {
+	n := len(ga.Population)
+	if len(m.birth) != n {
+		m.birth = make([]int, n)
+		for i := range m.birth {
+			m.birth[i] = i - n
+		}
+	}
+	m.worst = newIndexHeap(n, func(a, b int) bool { return ga.Population[a].Fitness < ga.Population[b].Fitness })
+	if m.Config.Replacement == SteadyReplaceOldest {
+		m.oldest = newIndexHeap(n, func(a, b int) bool { return m.birth[a] < m.birth[b] })
+	}
+	m.best = 0
+	for i, c := range ga.Population {
+		if c.Fitness > ga.Population[m.best].Fitness {
+			m.best = i
+		}
+	}
+}
+
+func (m *SteadyStateEvolutionModel) Evolve(ga *Algorithm) error {
+	if len(ga.Population) == 0 {
+		return errors.New("empty population")
+	}
+	m.prepare(ga)
+
+	births := m.Config.BirthsPerGeneration
+	if births == 0 {
+		births = ga.PopulationSize
+	}
+	batch := max(m.Config.Offspring, 1)
+
+	for born := 0; born < births; {
+		n := min(batch, births-born)
+		parents := ga.selectParents(ga.Population, 2*n)
+		children := make([]Chromosome, n)
+		for k := range children {
+			child := ga.CrossoverStrategy.Crossover(parents[2*k], parents[2*k+1])
+			ga.mutate(&child, parents[2*k], parents[2*k+1])
+			ga.repair(&child)
+			ga.evaluate(&child)
+			ga.reportOffspring(child)
+			children[k] = child
+		}
+		for k, child := range children {
+			m.insert(ga, child, parents[2*k], parents[2*k+1])
+		}
+		born += n
+	}
+
+	ga.SetLocalBest(ga.Population[m.best])
+	ga.adaptRates()
+	ga.checkStagnation()
+	ga.CurrentGeneration++
+	ga.Logger.LogGeneration(ga)
+
+	// Check if we should terminate and log completion
+	if ga.ShouldTerminate() {
+		ga.Logger.LogCompletion(ga)
+	}
+
+	return nil
+}
+
+// insert вставляет потомка по политике замены
+func (m *SteadyStateEvolutionModel) insert(ga *Algorithm, child, p1, p2 Chromosome) {
+	// Политики разнообразия (crowding, RTR, дубликаты) заменяют политику модели
+	if replaced, handled := ga.replaceInPopulation(ga.Population, child, p1, p2); handled {
+		if replaced >= 0 {
+			m.replaced(ga, replaced)
+		}
+		return
+	}
+
+	var target int
+	compete := true // Потомок занимает место, только если он лучше вытесняемой особи
+	switch m.Config.Replacement {
+	case SteadyReplaceRandom:
+		target, compete = rng.Intn(len(ga.Population)), false
+	case SteadyReplaceOldest:
+		target, compete = m.oldest.top(), false
+	case SteadyReplaceParent:
+		worse := p1
+		if p2.Fitness < p1.Fitness {
+			worse = p2
+		}
+		target = closestIndex(ga.Population, worse)
+	case SteadyReplaceCrowding:
+		target, compete = m.crowdingTarget(ga.Population, child), false
+	default:
+		target = m.worst.top()
+	}
+
+	if compete || target == m.best {
+		if child.Fitness <= ga.Population[target].Fitness {
+			return
+		}
+	}
+	ga.Population[target] = child
+	m.replaced(ga, target)
+}
+
+// replaced обновляет возраст, кучи и лучшую особь после замены на позиции idx
+func (m *SteadyStateEvolutionModel) replaced(ga *Algorithm, idx int) {
+	m.birth[idx] = m.clock
+	m.clock++
+	m.worst.fix(idx)
+	if m.oldest != nil {
+		m.oldest.fix(idx)
+	}
+	// Лучшую особь вытесняет только более приспособленный потомок, поэтому пересчёт не нужен
+	if ga.Population[idx].Fitness > ga.Population[m.best].Fitness {
+		m.best = idx
+	}
+	ga.SetBestSoFar(ga.Population[idx])
+}
+
+// resetSlots делает особей на позициях slots новорождёнными после перезапуска при стагнации
+func (m *SteadyStateEvolutionModel) resetSlots(_ *Algorithm, slots []int) {
+	if len(m.birth) == 0 {
+		return
+	}
+	for _, idx := range slots {
+		m.birth[idx] = m.clock
+		m.clock++
+	}
+}
+
+// crowdingTarget возвращает ближайшую к child особь среди CrowdingFactor случайных
+func (m *SteadyStateEvolutionModel) crowdingTarget(population []Chromosome, child Chromosome) int {
+	factor := m.Config.CrowdingFactor
+	if factor == 0 {
+		factor = 3
+	}
+	best, bestDist := 0, math.MaxInt
+	for k := 0; k < factor; k++ {
+		idx := rng.Intn(len(population))
+		if d := hammingDistance(child, population[idx]); d < bestDist {
+			best, bestDist = idx, d
+		}
+	}
+	return best
+}
+
+func (m *SteadyStateEvolutionModel) GetRequiredStrategies() []string {
+	return []string{"Selection", "Crossover", "Mutation"}
+}
+
+func (m *SteadyStateEvolutionModel) ValidateStrategies(ga *Algorithm) error {
+	if ga.SelectionStrategy.Strategy == nil {
+		return errors.New("selection strategy is required for steady state model")
+	}
+	if ga.CrossoverStrategy == nil {
+		return errors.New("crossover strategy is required for steady state model")
+	}
+	if ga.MutationStrategy == nil {
+		return errors.New("mutation strategy is required for steady state model")
+	}
+	return m.Config.Validate(ga.PopulationSize)
+}
+
+func (m *SteadyStateEvolutionModel) GetModelName() string {
+	return "SteadyState"
+}
+
+func (m *SteadyStateEvolutionModel) String() string {
+	return "SteadyState"
+}
@@ -22,6 +22,13 @@ const (
 	SteadyState
 	Memetic
 	Combined
+	NSGA2    // Многокритериальная модель (фронт Парето)
+	Cellular // Клеточная модель на тороидальной решётке
+	EDA      // Модель оценки распределения (PBIL, UMDA, компактный ГА)
+
+	SimulatedAnnealing // Имитация отжига (базовый метод для сравнения)
+	TabuSearch         // Поиск с запретами (базовый метод для сравнения)
+	AntColony          // Муравьиный алгоритм (только для паросочетания)
 )
 
 // Edge представляет ребро в графе
@@ -32,17 +39,23 @@ type Edge struct {
 
 // Graph представляет граф для задачи о максимальном паросочетании
 type Graph struct {
-	NumVertices int    // Количество вершин
-	Edges       []Edge // Список рёбер
+	NumVertices    int       // Количество вершин
+	Edges          []Edge    // Список рёбер
+	Costs          []float64 // Стоимости рёбер (nil — невзвешенный граф); параллельны Edges
+	RequirePerfect bool      // Требовать совершенное паросочетание (только для взвешенного графа)
+	Capacities     []int     // Ёмкости вершин для b-паросочетания (nil — все равны 1)
+	Preferences    [][]int   // Preferences[v] — соседи v от наиболее предпочтительного (nil — нет предпочтений)
 }
 
 // Config содержит основные параметры генетического алгоритма
 type Config struct {
-	UseFastRepair    bool    // Использовать быструю версию починки
-	UseCachedFitness bool    // Использовать кэширование значений приспособленности
-	PopulationSize   int     // Размер популяции
-	MutationRate     float64 // Вероятность мутации
-	Generations      int     // Максимальное число поколений
+	UseFastRepair    bool           // Использовать быструю версию починки
+	UseCachedFitness bool           // Использовать кэширование значений приспособленности
+	PopulationSize   int            // Размер популяции
+	MutationRate     float64        // Вероятность мутации
+	Generations      int            // Максимальное число поколений
+	Repair           RepairStrategy // Стратегия починки (nil — выбирается по UseFastRepair)
+	CacheSize        int            // Ёмкость кэша приспособленности (0 — 4096 записей)
 }
 
 // EvolutionModelConfig содержит настройки модели эволюции
@@ -53,6 +66,20 @@ type EvolutionModelConfig struct {
 	UseCrossover   bool
 	UseMutation    bool
 	UseLocalSearch bool
+
+	Diversity DiversityConfig // Механизмы сохранения разнообразия (поддерживаемые моделью, см. DiversityConfig.check)
+	Islands   IslandConfig    // Топология и политика миграции островной модели
+
+	LocalSearch    LocalSearchConfig    // Локальный поиск меметической и комбинированной моделей
+	Initialization InitializationConfig // Построение начальной популяции
+	Repair         RepairStrategy       // Починка потомков (nil — по индексам)
+	MultiObjective MultiObjectiveConfig // Целевые функции модели NSGA-II
+	SteadyState    SteadyStateConfig    // Размер шага и политика замены модели с постоянным состоянием
+	Cellular       CellularConfig       // Решётка и окрестности клеточной модели
+	EDA            EDAConfig            // Вариант и параметры модели оценки распределения
+	Annealing      AnnealingConfig      // Температуры имитации отжига
+	Tabu           TabuConfig           // Длительность запрета и число соседей поиска с запретами
+	ACO            ACOConfig            // Правило обновления феромона и веса муравьиного алгоритма
 }
 
 // Algorithm представляет основной класс генетического алгоритма
@@ -67,39 +94,43 @@ type Algorithm struct {
 	Generations       int
 	MutationRate      float64
 	CrossoverRate     float64
-	NumIslands        int // Для островной модели
-	MigrationInterval int // Число поколений между миграциями
-	CurrentGeneration int // Текущее поколение
+	NumIslands        int                  // Для островной модели
+	MigrationInterval int                  // Число поколений между миграциями
+	Islands           [][]Chromosome       // Постоянные острова островной модели
+	CurrentGeneration int                  // Текущее поколение
+	ModelConfig       EvolutionModelConfig // Конфигурация модели эволюции
+	Config            Config               // Общие настройки (починка, кэширование)
 
 	bestSoFar             Chromosome // Лучшая хромосома за всё время
 	localBest             Chromosome // Лучшая хромосома в текущей популяции
-	BestSoFarEdges        int        // Число рёбер в лучшем паросочетании за всё время
-	LocalBestEdges        int        // Число рёбер в лучшем паросочетании текущей популяции
-	optimalSize           int        // Оптимальный размер паросочетания (вычисляется алгоритмом Эдмондса)
+	BestSoFarEdges        int        // Число рёбер в лучшем паросочетании за всё время (для других задач — Problem.Objective)
+	LocalBestEdges        int        // Число рёбер в лучшем паросочетании текущей популяции (для других задач — Problem.Objective)
+	optimalSize           int        // Приспособленность оптимума из Problem.ReferenceOptimum (-1, если неизвестна)
 	useOptimalTermination bool       // Использовать ли оптимальное решение как условие остановки
 	Logger                *Logger    // Логгер для вывода информации
+
+	Problem      Problem            // Решаемая задача (по умолчанию — паросочетание)
+	fitnessCache *FitnessCache      // Кэш приспособленности (nil, если Config.UseCachedFitness выключен)
+	rates        *rateController    // Управление вероятностями (nil, если RateControl выключен)
+	stagnation   *stagnationMonitor // Реакция на стагнацию (nil, если она выключена)
 }
 
-// NewAlgorithm создаёт новый экземпляр генетического алгоритма
-func NewAlgorithm(graph *Graph, config Config) *Algorithm {
-	// Вычисляем оптимальное решение алгоритмом Эдмондса
-	optimalSize := MaxMatchingOld(graph)
-
-	return &Algorithm{
-		Graph:                 graph,
-		PopulationSize:        config.PopulationSize,
-		MutationRate:          config.MutationRate,
-		Generations:           config.Generations,
-		optimalSize:           optimalSize,
-		useOptimalTermination: true, // По умолчанию используем оптимальное решение
-		Logger:                NewLogger(),
-	}
+// NewAlgorithm создаёт алгоритм с параметрами config, классической моделью
+// и остановкой по достижении наибольшего паросочетания. Эквивалентен New с WithConfig.
+func NewAlgorithm(graph *Graph, config Config) (*Algorithm, error) {
+	return New(graph, WithConfig(config), WithOptimalTermination(true))
+}
+
+// OptimalSize возвращает размер наибольшего паросочетания графа
+// (для взвешенного графа и других задач — приспособленность эталонного оптимума, -1 если он неизвестен)
+func (ga *Algorithm) OptimalSize() int {
+	return ga.optimalSize
 }
 
 // ShouldTerminate проверяет условия остановки алгоритма
 func (ga *Algorithm) ShouldTerminate() bool {
 	// Проверяем достижение оптимального решения
-	if ga.useOptimalTermination && ga.bestSoFar.Fitness >= ga.optimalSize {
+	if ga.useOptimalTermination && ga.optimalSize >= 0 && ga.bestSoFar.Fitness >= ga.optimalSize {
 		return true
 	}
 
@@ -123,6 +154,18 @@ func (e EvolutionModel) String() string {
 		return "Memetic"
 	case Combined:
 		return "Combined"
+	case NSGA2:
+		return "NSGA2"
+	case Cellular:
+		return "Cellular"
+	case EDA:
+		return "EDA"
+	case SimulatedAnnealing:
+		return "SimulatedAnnealing"
+	case TabuSearch:
+		return "TabuSearch"
+	case AntColony:
+		return "ACO"
 	default:
 		return "Unknown"
 	}
@@ -136,10 +179,23 @@ type SelectionStrategy interface {
 	GetName() string
 }
 
+// BatchSelectionStrategy выбирает сразу n родителей за один проход,
+// что позволяет сортировать или сэмплировать популяцию один раз за поколение
+type BatchSelectionStrategy interface {
+	SelectionStrategy
+	SelectMany(population []Chromosome, n int) []Chromosome
+}
+
+// AlgorithmAwareSelection получает состояние алгоритма перед отбором
+// (номер поколения, граф и т.п.)
+type AlgorithmAwareSelection interface {
+	Prepare(ga *Algorithm)
+}
+
 // CrossoverStrategy определяет интерфейс для стратегий скрещивания
 type CrossoverStrategy interface {
 	Crossover(parent1, parent2 Chromosome) Chromosome
-	WithRate(rate float64) CrossoverStrategy
+	WithRate(rate float64) CrossoverStrategy // Копия стратегии с вероятностью rate; исходная не меняется
 	GetName() string
 }
 
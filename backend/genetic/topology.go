@@ -0,0 +1,297 @@
+package genetic
+
+import (
+	"fmt"
+	"sort"
+)
+
+// IslandTopology определяет, каким островам остров отправляет мигрантов
+type IslandTopology interface {
+	// Neighbors возвращает индексы островов-получателей для острова island
+	Neighbors(island, numIslands int) []int
+	GetName() string
+}
+
+// ------------------------------- Кольцо ------------------------------- //
+
+// RingTopology отправляет мигрантов следующему острову по кольцу
+type RingTopology struct{}
+
+func (t *RingTopology) Neighbors(island, numIslands int) []int {
+	if numIslands < 2 {
+		return nil
+	}
+	return []int{(island + 1) % numIslands}
+}
+
+func (t *RingTopology) GetName() string {
+	return "Ring"
+}
+
+// ------------------------- Полный граф ------------------------- //
+
+// FullyConnectedTopology отправляет мигрантов всем остальным островам
+type FullyConnectedTopology struct{}
+
+func (t *FullyConnectedTopology) Neighbors(island, numIslands int) []int {
+	neighbors := make([]int, 0, numIslands-1)
+	for j := 0; j < numIslands; j++ {
+		if j != island {
+			neighbors = append(neighbors, j)
+		}
+	}
+	return neighbors
+}
+
+func (t *FullyConnectedTopology) GetName() string {
+	return "FullyConnected"
+}
+
+// ------------------------------- Звезда ------------------------------- //
+
+// StarTopology соединяет центральный остров Hub со всеми остальными
+type StarTopology struct {
+	Hub int
+}
+
+func (t *StarTopology) Neighbors(island, numIslands int) []int {
+	hub := t.Hub
+	if hub < 0 || hub >= numIslands {
+		hub = 0
+	}
+	if island != hub {
+		return []int{hub}
+	}
+	neighbors := make([]int, 0, numIslands-1)
+	for j := 0; j < numIslands; j++ {
+		if j != hub {
+			neighbors = append(neighbors, j)
+		}
+	}
+	return neighbors
+}
+
+func (t *StarTopology) GetName() string {
+	return "Star"
+}
+
+// ------------------------- Случайный граф ------------------------- //
+
+// RandomTopology при каждой миграции выбирает Degree случайных островов-получателей
+type RandomTopology struct {
+	Degree int
+}
+
+func (t *RandomTopology) Neighbors(island, numIslands int) []int {
+	degree := t.Degree
+	if degree < 1 {
+		degree = 1
+	}
+	if degree > numIslands-1 {
+		degree = numIslands - 1
+	}
+	candidates := make([]int, 0, numIslands-1)
+	for j := 0; j < numIslands; j++ {
+		if j != island {
+			candidates = append(candidates, j)
+		}
+	}
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	return candidates[:degree]
+}
+
+func (t *RandomTopology) GetName() string {
+	return "Random"
+}
+
+// ------------------------------ Гиперкуб ------------------------------ //
+
+// HypercubeTopology соединяет острова, номера которых отличаются одним битом.
+// При числе островов, не равном степени двойки, несуществующие соседи пропускаются.
+type HypercubeTopology struct{}
+
+func (t *HypercubeTopology) Neighbors(island, numIslands int) []int {
+	var neighbors []int
+	for bit := 1; bit < numIslands; bit <<= 1 {
+		if j := island ^ bit; j < numIslands {
+			neighbors = append(neighbors, j)
+		}
+	}
+	return neighbors
+}
+
+func (t *HypercubeTopology) GetName() string {
+	return "Hypercube"
+}
+
+// ------------------------- Пользовательская ------------------------- //
+
+// CustomTopology задаёт получателей явно: Adjacency[i] — список соседей острова i
+type CustomTopology struct {
+	Adjacency [][]int
+}
+
+func (t *CustomTopology) Neighbors(island, numIslands int) []int {
+	if island >= len(t.Adjacency) {
+		return nil
+	}
+	var neighbors []int
+	for _, j := range t.Adjacency[island] {
+		if j >= 0 && j < numIslands && j != island {
+			neighbors = append(neighbors, j)
+		}
+	}
+	return neighbors
+}
+
+// Validate проверяет, что все соседи существуют
+func (t *CustomTopology) Validate(numIslands int) error {
+	for i, row := range t.Adjacency {
+		for _, j := range row {
+			if j < 0 || j >= numIslands {
+				return fmt.Errorf("custom topology: island %d references unknown island %d", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *CustomTopology) GetName() string {
+	return "Custom"
+}
+
+// ------------------------- Политика миграции ------------------------- //
+
+// EmigrantChoice определяет, какие особи покидают остров
+type EmigrantChoice int
+
+const (
+	EmigrateBest    EmigrantChoice = iota // Лучшие особи
+	EmigrateRandom                        // Случайные особи
+	EmigrateDiverse                       // Лучшая особь и наиболее удалённые от уже выбранных
+)
+
+// ImmigrantReplacement определяет, кого вытесняют прибывшие мигранты
+type ImmigrantReplacement int
+
+const (
+	ReplaceWorstIndividual  ImmigrantReplacement = iota // Худшие особи
+	ReplaceRandomIndividual                             // Случайные особи
+)
+
+// MigrationPolicy содержит параметры обмена особями между островами
+type MigrationPolicy struct {
+	Migrants    int                  // Число мигрантов на каждое ребро топологии
+	Emigrants   EmigrantChoice       // Выбор эмигрантов
+	Replacement ImmigrantReplacement // Выбор вытесняемых особей
+	Async       bool                 // Асинхронная миграция: острова мигрируют со сдвигом по фазе
+}
+
+// IslandConfig содержит настройки островной модели
+type IslandConfig struct {
+	Topology  IslandTopology
+	Migration MigrationPolicy
+	Operators []IslandOperators // Операторы по островам (пусто — у всех глобальные)
+}
+
+// SelectEmigrants возвращает особей острова, отправляемых соседям
+func SelectEmigrants(island []Chromosome, policy MigrationPolicy) []Chromosome {
+	n := policy.Migrants
+	if n < 1 {
+		n = 1
+	}
+	if n > len(island) {
+		n = len(island)
+	}
+
+	var chosen []Chromosome
+	switch policy.Emigrants {
+	case EmigrateRandom:
+		for _, idx := range rng.Perm(len(island))[:n] {
+			chosen = append(chosen, island[idx])
+		}
+	case EmigrateDiverse:
+		sorted := sortedByFitness(island)
+		chosen = append(chosen, sorted[0])
+		used := map[int]bool{0: true}
+		for len(chosen) < n {
+			// Жадный max-min: особь, максимально удалённая от уже выбранных
+			farthest, farthestDist := -1, -1
+			for i, c := range sorted {
+				if used[i] {
+					continue
+				}
+				minDist := -1
+				for _, ch := range chosen {
+					if d := hammingDistance(c, ch); minDist < 0 || d < minDist {
+						minDist = d
+					}
+				}
+				if minDist > farthestDist {
+					farthest, farthestDist = i, minDist
+				}
+			}
+			used[farthest] = true
+			chosen = append(chosen, sorted[farthest])
+		}
+	default:
+		chosen = sortedByFitness(island)[:n]
+	}
+
+	return chosen
+}
+
+// AcceptImmigrants вытесняет особей острова прибывшими мигрантами
+func AcceptImmigrants(island []Chromosome, migrants []Chromosome, policy MigrationPolicy) {
+	if len(migrants) > len(island) {
+		migrants = migrants[:len(island)]
+	}
+
+	var targets []int
+	switch policy.Replacement {
+	case ReplaceRandomIndividual:
+		targets = rng.Perm(len(island))[:len(migrants)]
+	default:
+		order := make([]int, len(island))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return island[order[a]].Fitness < island[order[b]].Fitness
+		})
+		targets = order[:len(migrants)]
+	}
+	// Каждый остров получает собственную копию генов мигранта
+	for k, idx := range targets {
+		island[idx] = copyChromosome(migrants[k])
+	}
+}
+
+// MigrateIslandsWith выполняет синхронную миграцию: сначала все острова выбирают эмигрантов,
+// затем мигранты одновременно прибывают к соседям по топологии
+func MigrateIslandsWith(islands [][]Chromosome, topology IslandTopology, policy MigrationPolicy) [][]Chromosome {
+	numIslands := len(islands)
+	incoming := make([][]Chromosome, numIslands)
+	for i := range islands {
+		emigrants := SelectEmigrants(islands[i], policy)
+		for _, j := range topology.Neighbors(i, numIslands) {
+			incoming[j] = append(incoming[j], emigrants...)
+		}
+	}
+	for j, migrants := range incoming {
+		if len(migrants) > 0 {
+			AcceptImmigrants(islands[j], migrants, policy)
+		}
+	}
+	return islands
+}
+
+// migrateIsland выполняет асинхронную миграцию одного острова: мигранты прибывают сразу
+func migrateIsland(islands [][]Chromosome, source int, topology IslandTopology, policy MigrationPolicy) {
+	emigrants := SelectEmigrants(islands[source], policy)
+	for _, j := range topology.Neighbors(source, len(islands)) {
+		AcceptImmigrants(islands[j], emigrants, policy)
+	}
+}
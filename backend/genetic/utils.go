@@ -1,7 +1,6 @@
 package genetic
 
 import (
-	"math/rand"
 	"sort"
 	"strings"
 )
@@ -22,23 +21,32 @@ func chromosomeKey(chrom Chromosome) string {
 
 // Evaluate вычисляет реальный размер паросочетания
 // Проверяет каждое включенное ребро на конфликты с уже использованными вершинами
-// Возвращает количество рёбер в допустимом паросочетании
+// (с учётом ёмкостей вершин для b-паросочетания)
+// Возвращает количество рёбер в допустимом паросочетании; для взвешенного графа
+// из count·W вычитается стоимость рёбер, так что меньшая стоимость важна только при равном числе рёбер
 func Evaluate(chrom *Chromosome, graph *Graph) {
-	used := make(map[int]bool)
+	load := newVertexLoad(graph)
 	count := 0
+	cost := 0
 
 	// Проверяем только включенные ребра
 	for i, gene := range chrom.Genes {
 		if gene {
 			edge := graph.Edges[i]
-			if !used[edge.U] && !used[edge.V] {
-				used[edge.U] = true
-				used[edge.V] = true
+			if load.fits(edge) {
+				load.add(edge)
 				count++
+				if graph.Costs != nil {
+					cost += scaledCost(graph.Costs[i])
+				}
 			}
 		}
 	}
 
+	if graph.Costs != nil {
+		chrom.Fitness = count*cardinalityWeight(graph) - cost
+		return
+	}
 	chrom.Fitness = count
 }
 
@@ -59,23 +67,22 @@ func EvaluateFast(chrom *Chromosome, graph *Graph) {
 // Удаляет рёбра, нарушающие условие паросочетания (общая вершина)
 // Обрабатывает рёбра в случайном порядке для увеличения разнообразия
 func Repair(chrom *Chromosome, graph *Graph) {
-	used := make(map[int]bool)
+	load := newVertexLoad(graph)
 	indices := make([]int, len(graph.Edges))
 	for i := range indices {
 		indices[i] = i
 	}
-	rand.Shuffle(len(indices), func(i, j int) {
+	rng.Shuffle(len(indices), func(i, j int) {
 		indices[i], indices[j] = indices[j], indices[i]
 	})
 
 	for _, idx := range indices {
 		if chrom.Genes[idx] {
 			edge := graph.Edges[idx]
-			if used[edge.U] || used[edge.V] {
-				chrom.Genes[idx] = false
+			if load.fits(edge) {
+				load.add(edge)
 			} else {
-				used[edge.U] = true
-				used[edge.V] = true
+				chrom.Genes[idx] = false
 			}
 		}
 	}
@@ -85,14 +92,13 @@ func Repair(chrom *Chromosome, graph *Graph) {
 // Обрабатывает рёбра в фиксированном порядке
 // Гарантирует одинаковый результат при одинаковых входных данных
 func RepairFast(chrom *Chromosome, graph *Graph) {
-	used := make(map[int]bool)
+	load := newVertexLoad(graph)
 
 	// Перебираем рёбра в фиксированном порядке
 	for idx, edge := range graph.Edges {
 		if chrom.Genes[idx] {
-			if !used[edge.U] && !used[edge.V] {
-				used[edge.U] = true
-				used[edge.V] = true
+			if load.fits(edge) {
+				load.add(edge)
 			} else {
 				chrom.Genes[idx] = false
 			}
@@ -282,6 +288,20 @@ func MaxMatchingOld(graph *Graph) int {
 	return result
 }
 
+// MaxMatching возвращает размер наибольшего паросочетания (алгоритм Эдмондса со сжатием цветков).
+// Используется как эталон для остановки и сравнения. Для графа с ёмкостями — размер MaxBMatching.
+func MaxMatching(graph *Graph) int {
+	if graph.Capacities != nil {
+		return len(ValidMatchingEdges(MaxBMatching(graph), graph))
+	}
+	chrom := Chromosome{Genes: make([]bool, len(graph.Edges))}
+	(&BlossomSearch{}).Improve(&chrom, graph)
+	size, _ := MatchingCost(chrom.Genes, graph)
+	return size
+}
+
+// MaxMatchingGreed возвращает размер жадного паросочетания при обходе рёбер по индексам.
+// Это нижняя оценка, а не оптимум.
 func MaxMatchingGreed(graph *Graph) int {
 	n := graph.NumVertices
 	used := make([]bool, n)
@@ -3,6 +3,7 @@ package backend
 import (
 	"Genetic-algorithm/backend/genetic"
 	"errors"
+	"fmt"
 	"image/color"
 	"os"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -40,6 +42,27 @@ func (s *GASolver) PlotResults() error {
 		}
 	}
 
+	// График 3: Разнообразие популяции от итераций для каждого графа
+	for _, graphName := range s.uniqueGraphNames() {
+		if err := s.plotDiversityVsIterations(dir, graphName); err != nil {
+			return err
+		}
+	}
+
+	// График 4: Фронт Парето и гиперобъём многокритериальной модели
+	for _, graphName := range s.uniqueGraphNames() {
+		if err := s.plotParetoFront(dir, graphName); err != nil {
+			return err
+		}
+	}
+
+	// График 5: Вероятности мутации и кроссовера по поколениям
+	for _, graphName := range s.uniqueGraphNames() {
+		if err := s.plotRates(dir, graphName); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -153,6 +176,163 @@ func (s *GASolver) plotFitnessAndEdgesVsIterations(dir, graphName string) error
 	return savePlot(p, filepath.Join(dir, filename))
 }
 
+func (s *GASolver) plotDiversityVsIterations(dir, graphName string) error {
+	p := plot.New()
+	p.Title.Text = "Разнообразие популяции: " + graphName
+	p.Title.TextStyle.Font.Size = 14
+	p.X.Label.Text = "Поколение"
+	p.Y.Label.Text = "Уникальных геномов"
+
+	// Настраиваем сетку
+	p.Add(plotter.NewGrid())
+
+	hasData := false
+	for _, res := range s.resultsForGraph(graphName) {
+		if len(res.DiversityHistory) == 0 {
+			continue
+		}
+		hasData = true
+		points := make(plotter.XYs, len(res.DiversityHistory))
+		for i, d := range res.DiversityHistory {
+			points[i] = plotter.XY{X: float64(i), Y: float64(d.UniqueGenomes)}
+		}
+
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return err
+		}
+		line.Color = getAlgorithmColor(res.Algorithm)
+		line.Width = vg.Points(2)
+		p.Add(line)
+		p.Legend.Add(res.Algorithm, line)
+	}
+	if !hasData {
+		return nil
+	}
+
+	// Настраиваем легенду
+	p.Legend.TextStyle.Font.Size = 10
+	p.Legend.Padding = 5
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	filename := "diversity_" + sanitizeFilename(graphName) + ".png"
+	return savePlot(p, filepath.Join(dir, filename))
+}
+
+func (s *GASolver) plotParetoFront(dir, graphName string) error {
+	var results []ExperimentResult
+	for _, res := range s.resultsForGraph(graphName) {
+		if len(res.ParetoFront) > 0 && len(res.ObjectiveNames) >= 2 {
+			results = append(results, res)
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	// Фронт: первые две цели, точки каждого запуска своим цветом
+	p := plot.New()
+	p.Title.Text = "Фронт Парето: " + graphName
+	p.Title.TextStyle.Font.Size = 14
+	p.X.Label.Text = results[0].ObjectiveNames[0]
+	p.Y.Label.Text = results[0].ObjectiveNames[1]
+	p.Add(plotter.NewGrid())
+	for i, res := range results {
+		points := make(plotter.XYs, len(res.ParetoFront))
+		for k, pt := range res.ParetoFront {
+			points[k] = plotter.XY{X: pt.Objectives[0], Y: pt.Objectives[1]}
+		}
+		scatter, err := plotter.NewScatter(points)
+		if err != nil {
+			return err
+		}
+		scatter.Color = plotutil.Color(i)
+		scatter.Shape = draw.CircleGlyph{}
+		scatter.Radius = vg.Points(4)
+		p.Add(scatter)
+		p.Legend.Add(fmt.Sprintf("%s #%d", res.Algorithm, i+1), scatter)
+	}
+	p.Legend.TextStyle.Font.Size = 10
+	p.Legend.Top = true
+	if err := savePlot(p, filepath.Join(dir, "pareto_"+sanitizeFilename(graphName)+".png")); err != nil {
+		return err
+	}
+
+	// Гиперобъём по поколениям
+	hv := plot.New()
+	hv.Title.Text = "Гиперобъём фронта Парето: " + graphName
+	hv.Title.TextStyle.Font.Size = 14
+	hv.X.Label.Text = "Поколение"
+	hv.Y.Label.Text = "Гиперобъём"
+	hv.Add(plotter.NewGrid())
+	for i, res := range results {
+		points := make(plotter.XYs, len(res.HypervolumeHistory))
+		for g, v := range res.HypervolumeHistory {
+			points[g] = plotter.XY{X: float64(g), Y: v}
+		}
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return err
+		}
+		line.Color = plotutil.Color(i)
+		line.Width = vg.Points(2)
+		hv.Add(line)
+		hv.Legend.Add(fmt.Sprintf("%s #%d", res.Algorithm, i+1), line)
+	}
+	hv.Legend.TextStyle.Font.Size = 10
+	hv.Legend.Top = true
+	hv.Legend.Left = true
+	return savePlot(hv, filepath.Join(dir, "hypervolume_"+sanitizeFilename(graphName)+".png"))
+}
+
+// plotRates строит траектории вероятностей: мутация — сплошной линией, кроссовер — пунктиром
+func (s *GASolver) plotRates(dir, graphName string) error {
+	var results []ExperimentResult
+	for _, res := range s.resultsForGraph(graphName) {
+		if len(res.RateHistory) > 0 {
+			results = append(results, res)
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	p := plot.New()
+	p.Title.Text = "Вероятности операторов: " + graphName
+	p.Title.TextStyle.Font.Size = 14
+	p.X.Label.Text = "Поколение"
+	p.Y.Label.Text = "Вероятность"
+	p.Add(plotter.NewGrid())
+	for i, res := range results {
+		mutation := make(plotter.XYs, len(res.RateHistory))
+		crossover := make(plotter.XYs, len(res.RateHistory))
+		for k, pt := range res.RateHistory {
+			mutation[k] = plotter.XY{X: float64(pt.Generation), Y: pt.MutationRate}
+			crossover[k] = plotter.XY{X: float64(pt.Generation), Y: pt.CrossoverRate}
+		}
+		mLine, err := plotter.NewLine(mutation)
+		if err != nil {
+			return err
+		}
+		mLine.Color = plotutil.Color(i)
+		mLine.Width = vg.Points(2)
+		cLine, err := plotter.NewLine(crossover)
+		if err != nil {
+			return err
+		}
+		cLine.Color = plotutil.Color(i)
+		cLine.Width = vg.Points(1)
+		cLine.Dashes = []vg.Length{vg.Points(4), vg.Points(3)}
+		p.Add(mLine, cLine)
+		p.Legend.Add(fmt.Sprintf("%s #%d: мутация", res.Algorithm, i+1), mLine)
+		p.Legend.Add(fmt.Sprintf("%s #%d: кроссовер", res.Algorithm, i+1), cLine)
+	}
+	p.Legend.TextStyle.Font.Size = 10
+	p.Legend.Top = true
+	return savePlot(p, filepath.Join(dir, "rates_"+sanitizeFilename(graphName)+".png"))
+}
+
 // Вспомогательные функции
 func (s *GASolver) uniqueGraphNames() []string {
 	seen := make(map[string]bool)
@@ -184,6 +364,13 @@ func getAlgorithmColor(algo string) color.Color {
 		"SteadyState": color.RGBA{R: 44, G: 160, B: 44, A: 255},   // Зеленый
 		"Memetic":     color.RGBA{R: 214, G: 39, B: 40, A: 255},   // Красный
 		"Combined":    color.RGBA{R: 148, G: 103, B: 189, A: 255}, // Фиолетовый
+		"NSGA2":       color.RGBA{R: 23, G: 190, B: 207, A: 255},  // Бирюзовый
+		"Cellular":    color.RGBA{R: 188, G: 189, B: 34, A: 255},  // Оливковый
+		"EDA":         color.RGBA{R: 227, G: 119, B: 194, A: 255}, // Розовый
+		"ACO":         color.RGBA{R: 255, G: 187, B: 120, A: 255}, // Персиковый
+
+		"SimulatedAnnealing": color.RGBA{R: 140, G: 86, B: 75, A: 255}, // Коричневый
+		"TabuSearch":         color.RGBA{R: 0, G: 0, B: 0, A: 255},     // Чёрный
 	}
 
 	if color, ok := colors[algo]; ok {
@@ -193,6 +380,114 @@ func getAlgorithmColor(algo string) color.Color {
 	return color.RGBA{R: 128, G: 128, B: 128, A: 255}
 }
 
+// ------ Ландшафт приспособленности ------ //
+
+// PlotLandscape сохраняет отчёт анализа ландшафта в JSON и строит по нему графики
+// в новой папке plots_<timestamp>; возвращает путь к папке
+func PlotLandscape(report genetic.LandscapeReport, graphName string) (string, error) {
+	dir := "plots_" + time.Now().Format("20060102-150405")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return "", err
+	}
+	name := sanitizeFilename(graphName)
+	if err := genetic.SaveLandscape(filepath.Join(dir, "landscape_"+name+".json"), report); err != nil {
+		return "", err
+	}
+
+	// Фитнес от расстояния до оптимума
+	fdc := plot.New()
+	fdc.Title.Text = fmt.Sprintf("Фитнес и расстояние до оптимума: %s (FDC = %.3f)", graphName, report.FDC.Correlation)
+	fdc.Title.TextStyle.Font.Size = 14
+	fdc.X.Label.Text = "Расстояние Хэмминга до оптимума"
+	fdc.Y.Label.Text = "Фитнес"
+	fdc.Add(plotter.NewGrid())
+	points := make(plotter.XYs, len(report.FDC.Points))
+	for i, pt := range report.FDC.Points {
+		points[i] = plotter.XY{X: float64(pt.Distance), Y: float64(pt.Fitness)}
+	}
+	scatter, err := plotter.NewScatter(points)
+	if err != nil {
+		return "", err
+	}
+	scatter.Color = plotutil.Color(0)
+	scatter.Shape = draw.CircleGlyph{}
+	scatter.Radius = vg.Points(3)
+	fdc.Add(scatter)
+	optimum, err := plotter.NewScatter(plotter.XYs{{X: 0, Y: float64(report.OptimumFitness)}})
+	if err != nil {
+		return "", err
+	}
+	optimum.Color = plotutil.Color(1)
+	optimum.Shape = draw.PyramidGlyph{}
+	optimum.Radius = vg.Points(6)
+	fdc.Add(optimum)
+	fdc.Legend.Add("Случайные решения", scatter)
+	fdc.Legend.Add("Оптимум", optimum)
+	fdc.Legend.TextStyle.Font.Size = 10
+	fdc.Legend.Top = true
+	if err := savePlot(fdc, filepath.Join(dir, "fdc_"+name+".png")); err != nil {
+		return "", err
+	}
+
+	// Автокорреляция блужданий по операторам мутации
+	ac := plot.New()
+	ac.Title.Text = "Автокорреляция случайных блужданий: " + graphName
+	ac.Title.TextStyle.Font.Size = 14
+	ac.X.Label.Text = "Сдвиг (шагов)"
+	ac.Y.Label.Text = "Автокорреляция фитнеса"
+	ac.Add(plotter.NewGrid())
+	for i, walk := range report.Walks {
+		points := make(plotter.XYs, len(walk.Autocorrelation))
+		for k, r := range walk.Autocorrelation {
+			points[k] = plotter.XY{X: float64(k), Y: r}
+		}
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return "", err
+		}
+		line.Color = plotutil.Color(i)
+		line.Width = vg.Points(2)
+		ac.Add(line)
+		ac.Legend.Add(fmt.Sprintf("%s (ℓ = %.1f, нейтральность %.2f)", walk.Strategy, walk.CorrelationLength, walk.Neutrality), line)
+	}
+	ac.Legend.TextStyle.Font.Size = 10
+	ac.Legend.Top = true
+	if err := savePlot(ac, filepath.Join(dir, "autocorrelation_"+name+".png")); err != nil {
+		return "", err
+	}
+
+	// Локальные оптимумы по уровням фитнеса (только для перебранных малых графов)
+	if !report.LocalOptima.Computed || len(report.LocalOptima.Levels) == 0 {
+		return dir, nil
+	}
+	lo := plot.New()
+	lo.Title.Text = fmt.Sprintf("Локальные оптимумы: %s (%d из %d решений)",
+		graphName, report.LocalOptima.LocalOptima, report.LocalOptima.Solutions)
+	lo.Title.TextStyle.Font.Size = 14
+	lo.X.Label.Text = "Фитнес"
+	lo.Y.Label.Text = "Число локальных оптимумов"
+	lo.Add(plotter.NewGrid())
+	counts := make(plotter.Values, len(report.LocalOptima.Levels))
+	labels := make([]string, len(report.LocalOptima.Levels))
+	for i, level := range report.LocalOptima.Levels {
+		// Уровни отсортированы по убыванию фитнеса, на графике — по возрастанию
+		j := len(counts) - 1 - i
+		counts[j] = float64(level.Count)
+		labels[j] = fmt.Sprint(level.Fitness)
+	}
+	bars, err := plotter.NewBarChart(counts, vg.Points(20))
+	if err != nil {
+		return "", err
+	}
+	bars.Color = plotutil.Color(2)
+	lo.Add(bars)
+	lo.NominalX(labels...)
+	if err := savePlot(lo, filepath.Join(dir, "local_optima_"+name+".png")); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
 func savePlot(p *plot.Plot, filename string) error {
 	// Увеличиваем размер графика для лучшей читаемости
 	return p.Save(12*vg.Inch, 8*vg.Inch, filename)
@@ -217,42 +512,40 @@ func (s *GASolver) RunExperimentsVaryingSize(
 			graph := generateRandomGraph(size)
 			graphName := params.EvolutionModel.String() + "_size" + string(rune(size))
 			start := time.Now()
+			// Затравочные паросочетания относятся к другому графу
+			initialization := params.Initialization
+			initialization.Seeds = nil
 			// Run the solver for this graph and params
-			ga, err := genetic.NewGeneticAlgorithm(
-				&graph,
-				params.EvolutionModel,
-				params.CrossoverStrategy,
-				params.SelectionStrategy,
-				params.MutationStrategy,
-				params.PopulationSize,
-				params.Generations,
-				params.MutationRate,
-				params.CrossoverRate,
-				params.NumIslands,
-				params.MigrationInterval,
-			)
+			ga, err := genetic.New(&graph, append(params.Options(), genetic.WithInitialization(initialization))...)
 			if err != nil {
 				return err
 			}
 			ga.InitializePopulation()
 			for gen := 1; gen <= ga.Generations; gen++ {
-				ga.CurrentGeneration = gen
 				if err := ga.EvolutionModel.Evolve(ga); err != nil {
 					return err
 				}
 			}
 			finalBest := ga.GetBestSoFar()
-			finalBestValid := countValidMatchingEdges(finalBest, ga.Graph)
+			if finalBest.Genes == nil {
+				// Без внешнего цикла лучшее за всё время не отслеживается — берём лучшее в популяции
+				finalBest = ga.GetBestChromosome()
+			}
+			finalBestValid := ga.Problem.Objective(finalBest.Genes)
 			result := ExperimentResult{
 				GraphName:           graphName,
 				Algorithm:           params.EvolutionModel.String(),
+				Problem:             params.Problem.String(),
 				GraphVertices:       graph.NumVertices,
 				GraphEdges:          len(graph.Edges),
 				TimeTaken:           time.Since(start),
 				BestFitness:         finalBestValid,
 				FitnessHistory:      []int{},
-				BestMatchingEdges:   getValidMatchingEdges(finalBest, ga.Graph),
 				BestChromosomeGenes: make([]bool, len(finalBest.Genes)),
+				BestDescription:     ga.Problem.DescribeSolution(finalBest.Genes),
+			}
+			if ga.Problem.Type() == genetic.Matching {
+				result.BestMatchingEdges = getValidMatchingEdges(finalBest, ga.Graph)
 			}
 			copy(result.BestChromosomeGenes, finalBest.Genes)
 			s.Results = append(s.Results, result)
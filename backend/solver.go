@@ -4,8 +4,6 @@ import (
 	"Genetic-algorithm/backend/genetic"
 	"log"
 	"time"
-
-	"github.com/fatih/color"
 )
 
 // Params содержит параметры для запуска генетического алгоритма
@@ -20,22 +18,91 @@ type Params struct {
 	CrossoverRate     float64
 	NumIslands        int
 	MigrationInterval int
-	TournamentSize    int            // Новый параметр для турнирной селекции
-	Config            genetic.Config // Конфигурация генетического алгоритма
+	TournamentSize    int                          // Новый параметр для турнирной селекции
+	Config            genetic.Config               // Конфигурация генетического алгоритма
+	Diversity         genetic.DiversityConfig      // Механизмы сохранения разнообразия
+	Islands           genetic.IslandConfig         // Топология и политика миграции островов
+	LocalSearch       genetic.LocalSearchConfig    // Локальный поиск меметической модели
+	Initialization    genetic.InitializationConfig // Инициализаторы и затравочные паросочетания
+	Problem           genetic.ProblemType          // Решаемая задача (по умолчанию — паросочетание)
+	MultiObjective    genetic.MultiObjectiveConfig // Целевые функции модели NSGA-II
+	RateControl       genetic.RateControlConfig    // Расписания и адаптация вероятностей
+	Stagnation        genetic.StagnationConfig     // Реакция на стагнацию лучшего решения
+	SteadyState       genetic.SteadyStateConfig    // Размер шага и политика замены модели с постоянным состоянием
+	Cellular          genetic.CellularConfig       // Решётка и окрестности клеточной модели
+	EDA               genetic.EDAConfig            // Вариант и параметры модели оценки распределения
+	Annealing         genetic.AnnealingConfig      // Имитация отжига
+	Tabu              genetic.TabuConfig           // Поиск с запретами
+	ACO               genetic.ACOConfig            // Муравьиный алгоритм
+}
+
+// Options преобразует параметры в опции конструктора genetic.New
+func (p Params) Options() []genetic.Option {
+	return []genetic.Option{
+		genetic.WithConfig(p.Config),
+		genetic.WithModel(p.EvolutionModel),
+		genetic.WithOperators(p.SelectionStrategy, p.CrossoverStrategy, p.MutationStrategy),
+		genetic.WithPopulation(p.PopulationSize, p.Generations),
+		genetic.WithRates(p.MutationRate, p.CrossoverRate),
+		genetic.WithIslandCount(p.NumIslands, p.MigrationInterval),
+		genetic.WithDiversity(p.Diversity),
+		genetic.WithIslands(p.Islands),
+		genetic.WithLocalSearch(p.LocalSearch),
+		genetic.WithInitialization(p.Initialization),
+		genetic.WithProblem(p.Problem),
+		genetic.WithMultiObjective(p.MultiObjective),
+		genetic.WithRateControl(p.RateControl),
+		genetic.WithStagnation(p.Stagnation),
+		genetic.WithSteadyState(p.SteadyState),
+		genetic.WithCellular(p.Cellular),
+		genetic.WithEDA(p.EDA),
+		genetic.WithBaselines(p.Annealing, p.Tabu),
+		genetic.WithACO(p.ACO),
+	}
 }
 
 // ExperimentResult содержит результаты одного эксперимента
 type ExperimentResult struct {
 	GraphName           string
 	Algorithm           string
+	Problem             string // Решаемая задача (genetic.ProblemType)
 	GraphVertices       int
 	GraphEdges          int
 	TimeTaken           time.Duration
 	BestFitness         int
 	AverageFitness      float64
 	FitnessHistory      []int
-	BestMatchingEdges   []int  // Индексы рёбер в наибольшем допустимом паросочетании
-	BestChromosomeGenes []bool // Гены лучшей хромосомы
+	BestMatchingEdges   []int                    // Индексы рёбер в наибольшем допустимом паросочетании (только для паросочетания)
+	BestDescription     string                   // Описание лучшего решения задачи
+	BestChromosomeGenes []bool                   // Гены лучшей хромосомы
+	CrossoverStats      []genetic.OperatorStats  // Статистика под-операторов комбинированного кроссовера
+	DiversityHistory    []genetic.DiversityStats // Разнообразие популяции по поколениям
+	CacheHistory        []genetic.CacheStats     // Накопленная статистика кэша фитнеса по поколениям
+	IslandStats         []genetic.IslandStats    // Итоговая статистика по островам (островная модель)
+	RateHistory         []genetic.RatePoint      // Вероятности мутации и кроссовера по поколениям (при управлении вероятностями)
+	RestartEvents       []genetic.RestartEvent   // Срабатывания реакции на стагнацию
+	PheromoneLevels     []float64                // Феромон на рёбрах после последнего поколения, нормированный на максимум (ACO)
+
+	// Взвешенный граф (задача о назначениях)
+	Weighted    bool      // Граф имеет стоимости рёбер
+	BestCost    float64   // Стоимость лучшего найденного паросочетания
+	OptimalCost float64   // Стоимость оптимального назначения (венгерский алгоритм)
+	OptimalSize int       // Число рёбер оптимального назначения
+	CostGap     float64   // Относительный разрыв (BestCost - OptimalCost) / |OptimalCost| (0 при OptimalCost = 0)
+	CostDelta   float64   // Абсолютный разрыв BestCost - OptimalCost
+	Feasible    bool      // Найденное паросочетание имеет мощность оптимального (совершенное, если оно требуется)
+	Imperfect   bool      // Требовалось совершенное паросочетание, но лучшее найденное им не является
+	CostHistory []float64 // Стоимость лучшего паросочетания по поколениям
+
+	// Устойчивое паросочетание
+	PreferenceBased bool                  // Решалась задача об устойчивом паросочетании
+	StableMetrics   genetic.StableMetrics // Показатели лучшего решения
+	GaleShapley     genetic.StableMetrics // Показатели паросочетания Гейла–Шепли
+
+	// Многокритериальная модель (NSGA-II)
+	ObjectiveNames     []string              // Названия целевых функций
+	ParetoFront        []genetic.ParetoPoint // Итоговый фронт Парето
+	HypervolumeHistory []float64             // Гиперобъём фронта по поколениям
 }
 
 // GASolver представляет решатель задачи о максимальном паросочетании
@@ -49,6 +116,17 @@ type GASolver struct {
 	BestSolution genetic.Chromosome
 	UpdateChan   chan genetic.Chromosome
 	Results      []ExperimentResult
+
+	// OnIslandStats вызывается после каждого поколения островной модели
+	OnIslandStats func([]genetic.IslandStats)
+	// OnParetoFront вызывается после каждого поколения модели NSGA-II
+	OnParetoFront func(front []genetic.ParetoPoint, objectives []string, hypervolume float64)
+	// OnCellularGrid вызывается после каждого поколения клеточной модели
+	OnCellularGrid func(width, height int, fitness []int)
+	// OnGeneProbabilities вызывается после каждого поколения модели оценки распределения
+	OnGeneProbabilities func(probs []float64)
+	// OnPheromone вызывается после каждого поколения муравьиного алгоритма с феромоном, нормированным на максимум
+	OnPheromone func(levels []float64)
 }
 
 // NewGASolver создаёт новый экземпляр решателя
@@ -61,6 +139,42 @@ func NewGASolver(graph *genetic.Graph, params Params) *GASolver {
 	}
 }
 
+// runGenerations выполняет основной цикл над инициализированной популяцией: эволюционирует до
+// ga.Generations поколений, после каждого обновляет лучшее за всё время (починив и переоценив
+// особь) и вызывает onGeneration с лучшей особью поколения. Цикл завершается досрочно, если
+// onGeneration вернула false или достигнута приспособленность target (0 — цель неизвестна).
+// Счётчик CurrentGeneration ведут модели: Evolve увеличивает его, так что после поколения gen он равен gen.
+func runGenerations(ga *genetic.Algorithm, target int, onGeneration func(current genetic.Chromosome) bool) error {
+	for gen := 1; gen <= ga.Generations; gen++ {
+		if err := ga.EvolutionModel.Evolve(ga); err != nil {
+			return err
+		}
+
+		// Классическая модель не ведёт лучшее за всё время: его отслеживает внешний цикл
+		current := ga.GetBestChromosome()
+		ga.SetLocalBest(current)
+		if ga.IsBetterThanBestSoFar(current) {
+			if ga.Problem.Type() == genetic.Matching {
+				genetic.RepairFast(&current, ga.Graph)
+			} else {
+				ga.Problem.Repair(&current)
+			}
+			ga.Problem.Evaluate(&current)
+			ga.SetBestSoFar(current)
+			ga.Logger.LogMilestone("Найдено новое лучшее паросочетание: %d рёбер (поколение %d)", ga.BestSoFarEdges, ga.CurrentGeneration)
+		}
+		if onGeneration != nil && !onGeneration(current) {
+			return nil
+		}
+
+		if target > 0 && ga.GetBestSoFar().Fitness >= target {
+			ga.Logger.LogSuccess("Reached optimal at gen %d", gen)
+			return nil
+		}
+	}
+	return nil
+}
+
 func (s *GASolver) Start(graph genetic.Graph, params Params, graphName string) {
 	s.IsRunning = true
 	s.Done = make(chan struct{}) // Create new done channel
@@ -70,6 +184,7 @@ func (s *GASolver) Start(graph genetic.Graph, params Params, graphName string) {
 	result := ExperimentResult{
 		GraphName:      graphName,
 		Algorithm:      params.EvolutionModel.String(),
+		Problem:        params.Problem.String(),
 		GraphVertices:  graph.NumVertices,
 		GraphEdges:     len(graph.Edges),
 		FitnessHistory: make([]int, 0, params.Generations),
@@ -82,19 +197,7 @@ func (s *GASolver) Start(graph genetic.Graph, params Params, graphName string) {
 			close(s.Done)       // Then signal completion
 		}()
 
-		ga, err := genetic.NewGeneticAlgorithm(
-			&graph,
-			params.EvolutionModel,
-			params.CrossoverStrategy,
-			params.SelectionStrategy,
-			params.MutationStrategy,
-			params.PopulationSize,
-			params.Generations,
-			params.MutationRate,
-			params.CrossoverRate,
-			params.NumIslands,
-			params.MigrationInterval,
-		)
+		ga, err := genetic.New(&graph, params.Options()...)
 		if err != nil {
 			log.Println(err)
 			return
@@ -103,17 +206,8 @@ func (s *GASolver) Start(graph genetic.Graph, params Params, graphName string) {
 		// Log algorithm start
 		ga.Logger.LogAlgorithmStart(ga)
 
-		// Вычисление условия остановки работы
-		var target int
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					color.Yellow("MaxMatchingGreed failed (%v), disabling early stop", r)
-					target = 0
-				}
-			}()
-			target = genetic.MaxMatchingGreed(ga.Graph)
-		}()
+		// Условие остановки: наибольшее паросочетание, вычисленное при создании алгоритма
+		target := ga.OptimalSize()
 
 		// Log target
 		if target > 0 {
@@ -134,47 +228,97 @@ func (s *GASolver) Start(graph genetic.Graph, params Params, graphName string) {
 		s.UpdateChan <- best
 
 		// Основной цикл
-		for gen := 1; gen <= ga.Generations && s.IsRunning; gen++ {
-			ga.CurrentGeneration = gen
-			// Evolve population using the selected model strategy
-			if err := ga.EvolutionModel.Evolve(ga); err != nil {
-				log.Printf("Error in evolution: %v", err)
-				break
-			}
-
-			// Лог и обновление лучшего
-			current := ga.GetBestChromosome()
-			ga.SetLocalBest(current)
-			if ga.LocalBestEdges > ga.BestSoFarEdges {
-				genetic.RepairFast(&current, ga.Graph)
-				genetic.Evaluate(&current, ga.Graph)
-				ga.SetBestSoFar(current)
-				ga.Logger.LogMilestone("Найдено новое лучшее паросочетание: %d рёбер (поколение %d)", ga.BestSoFarEdges, ga.CurrentGeneration)
-			}
+		err = runGenerations(ga, target, func(current genetic.Chromosome) bool {
 			best = current
 			s.UpdateChan <- best
 			result.FitnessHistory = append(result.FitnessHistory, ga.BestSoFarEdges)
-
-			// Досрочный выход
-			if target > 0 && ga.BestSoFarEdges >= target {
-				ga.Logger.LogSuccess("Reached optimal at gen %d", gen)
-				break
+			result.DiversityHistory = append(result.DiversityHistory, genetic.ComputeDiversity(ga.Population))
+			if graph.Costs != nil {
+				_, cost := genetic.MatchingCost(ga.GetBestSoFar().Genes, ga.Graph)
+				result.CostHistory = append(result.CostHistory, cost)
+			}
+			if stats, ok := ga.CacheStats(); ok {
+				result.CacheHistory = append(result.CacheHistory, stats)
+			}
+			if front, hv, ok := ga.ParetoFront(); ok {
+				result.ObjectiveNames = ga.ObjectiveNames()
+				result.ParetoFront = front
+				result.HypervolumeHistory = append(result.HypervolumeHistory, hv)
+				if s.OnParetoFront != nil {
+					s.OnParetoFront(front, result.ObjectiveNames, hv)
+				}
+			}
+			if width, height, fitness, ok := ga.CellularGrid(); ok && s.OnCellularGrid != nil {
+				s.OnCellularGrid(width, height, fitness)
+			}
+			if probs, ok := ga.GeneProbabilities(); ok && s.OnGeneProbabilities != nil {
+				s.OnGeneProbabilities(probs)
+			}
+			if levels, ok := ga.PheromoneLevels(); ok {
+				result.PheromoneLevels = levels
+				if s.OnPheromone != nil {
+					s.OnPheromone(levels)
+				}
+			}
+			if len(ga.Islands) > 0 {
+				result.IslandStats = ga.IslandStatistics()
+				if s.OnIslandStats != nil {
+					s.OnIslandStats(result.IslandStats)
+				}
 			}
 
+			return s.IsRunning
+		})
+		if err != nil {
+			log.Printf("Error in evolution: %v", err)
 		}
 
 		finalBest := ga.GetBestSoFar()
-		finalBestValid := countValidMatchingEdges(finalBest, ga.Graph)
-		ga.Logger.LogSuccess("GA finished. Best matching = %d", finalBestValid)
+		finalBestValid := ga.Problem.Objective(finalBest.Genes)
+		ga.Logger.LogSuccess("GA finished. Best %v = %d", ga.Problem.Type(), finalBestValid)
 
 		// Фиксируем результаты
 		result.TimeTaken = time.Since(startTime)
 		result.BestFitness = finalBestValid
 		// Сохраняем индексы рёбер наибольшего паросочетания из глобального bestSoFar
 		globalBest := ga.GetBestSoFar()
-		result.BestMatchingEdges = getValidMatchingEdges(globalBest, ga.Graph)
+		if ga.Problem.Type() == genetic.Matching {
+			result.BestMatchingEdges = getValidMatchingEdges(globalBest, ga.Graph)
+		}
+		result.BestDescription = ga.Problem.DescribeSolution(globalBest.Genes)
 		result.BestChromosomeGenes = make([]bool, len(globalBest.Genes))
 		copy(result.BestChromosomeGenes, globalBest.Genes)
+		if ref, ok := ga.AssignmentReference(); ok {
+			size, cost := genetic.MatchingCost(globalBest.Genes, ga.Graph)
+			result.Weighted = true
+			result.BestCost = cost
+			result.OptimalCost = ref.Cost
+			result.OptimalSize = ref.Size
+			result.CostGap = genetic.CostGap(cost, ref.Cost)
+			result.CostDelta = cost - ref.Cost
+			result.Feasible = size == ref.Size
+			result.Imperfect = graph.RequirePerfect && 2*size != graph.NumVertices
+			ga.Logger.LogInfo("Назначение: стоимость=%.3f, оптимум=%.3f (разрыв %.2f%%, %+.3f), рёбер=%d из %d",
+				cost, ref.Cost, 100*result.CostGap, result.CostDelta, size, ref.Size)
+			if result.Imperfect {
+				ga.Logger.LogWarning("Требовалось совершенное паросочетание, найдено покрывающее %d из %d вершин",
+					2*size, graph.NumVertices)
+			}
+		}
+		if ref, ok := ga.StableReference(); ok {
+			result.PreferenceBased = true
+			result.StableMetrics = ga.Problem.(*genetic.StableMatchingProblem).Metrics(globalBest.Genes)
+			result.GaleShapley = ref.Metrics
+			ga.Logger.LogInfo("Устойчивость: блокирующих пар=%d, эгалитарная стоимость=%d (Гейл–Шепли: %d), профиль рангов=%v (Гейл–Шепли: %v)",
+				result.StableMetrics.BlockingPairs, result.StableMetrics.EgalitarianCost, ref.Metrics.EgalitarianCost,
+				result.StableMetrics.RankProfile, ref.Metrics.RankProfile)
+		}
+		result.RateHistory = ga.RateHistory()
+		result.RestartEvents = ga.RestartEvents()
+		if cc, ok := ga.CrossoverStrategy.(*genetic.CombinedCrossover); ok {
+			result.CrossoverStats = cc.Stats()
+			ga.Logger.LogOperatorStats("кроссовера", result.CrossoverStats)
+		}
 		s.Results = append(s.Results, result)
 
 		s.BestSolution = finalBest
@@ -187,19 +331,7 @@ func (s *GASolver) Stop() {
 }
 
 func (s *GASolver) Run() error {
-	ga, err := genetic.NewGeneticAlgorithm(
-		s.Graph,
-		s.Params.EvolutionModel,
-		s.Params.CrossoverStrategy,
-		s.Params.SelectionStrategy,
-		s.Params.MutationStrategy,
-		s.Params.PopulationSize,
-		s.Params.Generations,
-		s.Params.MutationRate,
-		s.Params.CrossoverRate,
-		s.Params.NumIslands,
-		s.Params.MigrationInterval,
-	)
+	ga, err := genetic.New(s.Graph, s.Params.Options()...)
 	if err != nil {
 		return err
 	}
@@ -227,36 +359,12 @@ func (s *GASolver) Run() error {
 	return nil
 }
 
-// getValidMatchingEdges возвращает индексы рёбер в допустимом паросочетании для данной хромосомы
+// getValidMatchingEdges возвращает индексы рёбер допустимого паросочетания (с учётом ёмкостей вершин)
 func getValidMatchingEdges(chrom genetic.Chromosome, graph *genetic.Graph) []int {
-	used := make(map[int]bool)
-	var indices []int
-	for i, gene := range chrom.Genes {
-		if gene {
-			edge := graph.Edges[i]
-			if !used[edge.U] && !used[edge.V] {
-				used[edge.U] = true
-				used[edge.V] = true
-				indices = append(indices, i)
-			}
-		}
-	}
-	return indices
+	return genetic.ValidMatchingEdges(chrom.Genes, graph)
 }
 
 // countValidMatchingEdges возвращает количество рёбер в допустимом паросочетании для данной хромосомы
 func countValidMatchingEdges(chrom genetic.Chromosome, graph *genetic.Graph) int {
-	used := make(map[int]bool)
-	count := 0
-	for i, gene := range chrom.Genes {
-		if gene {
-			edge := graph.Edges[i]
-			if !used[edge.U] && !used[edge.V] {
-				used[edge.U] = true
-				used[edge.V] = true
-				count++
-			}
-		}
-	}
-	return count
+	return len(genetic.ValidMatchingEdges(chrom.Genes, graph))
 }
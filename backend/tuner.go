@@ -0,0 +1,633 @@
+package backend
+
+import (
+	"Genetic-algorithm/backend/genetic"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Автоматический подбор параметров: случайный поиск, гонка с последовательным отсевом
+// (в духе irace) и байесовская оптимизация с гауссовским процессом. Конфигурация оценивается
+// запусками на обучающих экземплярах — парах (граф, seed). Оценка запуска — лучшая найденная
+// приспособленность, отнесённая к оптимуму графа, а если он неизвестен — к лучшему результату
+// на этом графе среди всех запусков подбора; оценка конфигурации — среднее по её запускам.
+
+// ------------------------ Настройки ------------------------ //
+
+// TuningMethod определяет способ подбора параметров
+type TuningMethod int
+
+const (
+	// TuneRandomSearch — Candidates случайных конфигураций на всех экземплярах
+	TuneRandomSearch TuningMethod = iota
+	// TuneRacing — Candidates конфигураций; после каждого раунда на новых экземплярах худшая половина выбывает
+	TuneRacing
+	// TuneBayesian — конфигурации по одной предлагает ожидаемое улучшение гауссовского процесса
+	TuneBayesian
+)
+
+func (m TuningMethod) String() string {
+	switch m {
+	case TuneRandomSearch:
+		return "RandomSearch"
+	case TuneRacing:
+		return "Racing"
+	case TuneBayesian:
+		return "Bayesian"
+	default:
+		return "Unknown"
+	}
+}
+
+// IntRange — диапазон целого параметра; нулевой диапазон оставляет значение из TunerConfig.Base
+type IntRange struct{ Min, Max int }
+
+// FloatRange — диапазон вещественного параметра; нулевой диапазон оставляет значение из TunerConfig.Base
+type FloatRange struct{ Min, Max float64 }
+
+// TuningSpace задаёт подбираемые параметры
+type TuningSpace struct {
+	PopulationSize    IntRange
+	MutationRate      FloatRange // Сэмплируется логарифмически равномерно
+	CrossoverRate     FloatRange
+	TournamentSize    IntRange // Только для турнирной селекции
+	NumIslands        IntRange // Только для островной модели
+	MigrationInterval IntRange // Только для островной модели
+}
+
+// DefaultTuningSpace возвращает диапазоны параметров, влияющих на base: размер турнира —
+// только при турнирной селекции, острова и интервал миграции — только для островной модели
+func DefaultTuningSpace(base Params) TuningSpace {
+	space := TuningSpace{
+		PopulationSize: IntRange{Min: 20, Max: 200},
+		MutationRate:   FloatRange{Min: 0.001, Max: 0.2},
+		CrossoverRate:  FloatRange{Min: 0.5, Max: 1},
+	}
+	if _, ok := base.SelectionStrategy.(*genetic.TournamentSelectionStrategy); ok {
+		space.TournamentSize = IntRange{Min: 2, Max: 8}
+	}
+	if base.EvolutionModel == genetic.Island {
+		space.NumIslands = IntRange{Min: 2, Max: 8}
+		space.MigrationInterval = IntRange{Min: 1, Max: 20}
+	}
+	return space
+}
+
+// Validate проверяет диапазоны
+func (s TuningSpace) Validate() error {
+	ints := []struct {
+		name   string
+		r      IntRange
+		lowest int
+	}{
+		{"population size", s.PopulationSize, 2},
+		{"tournament size", s.TournamentSize, 1},
+		{"number of islands", s.NumIslands, 1},
+		{"migration interval", s.MigrationInterval, 1},
+	}
+	for _, d := range ints {
+		if d.r != (IntRange{}) && (d.r.Min < d.lowest || d.r.Max < d.r.Min) {
+			return fmt.Errorf("%s range must satisfy %d <= min <= max, got [%d, %d]", d.name, d.lowest, d.r.Min, d.r.Max)
+		}
+	}
+	if r := s.MutationRate; r != (FloatRange{}) && (r.Min <= 0 || r.Max > 1 || r.Max < r.Min) {
+		return fmt.Errorf("mutation rate range must satisfy 0 < min <= max <= 1, got [%v, %v]", r.Min, r.Max)
+	}
+	if r := s.CrossoverRate; r != (FloatRange{}) && (r.Min < 0 || r.Max > 1 || r.Max < r.Min) {
+		return fmt.Errorf("crossover rate range must satisfy 0 <= min <= max <= 1, got [%v, %v]", r.Min, r.Max)
+	}
+	return nil
+}
+
+// tuningDim записывает в параметры значение подбираемого параметра для u ∈ [0, 1]
+type tuningDim func(p *Params, u float64)
+
+// dims возвращает подбираемые параметры пространства
+func (s TuningSpace) dims() []tuningDim {
+	var dims []tuningDim
+	addInt := func(r IntRange, set func(p *Params, v int)) {
+		if r == (IntRange{}) {
+			return
+		}
+		dims = append(dims, func(p *Params, u float64) {
+			set(p, r.Min+int(math.Round(u*float64(r.Max-r.Min))))
+		})
+	}
+
+	addInt(s.PopulationSize, func(p *Params, v int) { p.PopulationSize = v })
+	if r := s.MutationRate; r != (FloatRange{}) {
+		dims = append(dims, func(p *Params, u float64) {
+			p.MutationRate = r.Min * math.Pow(r.Max/r.Min, u)
+		})
+	}
+	if r := s.CrossoverRate; r != (FloatRange{}) {
+		dims = append(dims, func(p *Params, u float64) {
+			p.CrossoverRate = r.Min + u*(r.Max-r.Min)
+		})
+	}
+	addInt(s.TournamentSize, func(p *Params, v int) {
+		p.TournamentSize = v
+		// Стратегия базовых параметров общая для всех кандидатов, поэтому создаётся новая
+		if _, ok := p.SelectionStrategy.(*genetic.TournamentSelectionStrategy); ok {
+			p.SelectionStrategy = &genetic.TournamentSelectionStrategy{TournamentSize: v}
+		}
+	})
+	addInt(s.NumIslands, func(p *Params, v int) { p.NumIslands = v })
+	addInt(s.MigrationInterval, func(p *Params, v int) { p.MigrationInterval = v })
+	return dims
+}
+
+// TuningGraph — обучающий граф
+type TuningGraph struct {
+	Name  string
+	Graph genetic.Graph
+}
+
+// TunerConfig задаёт подбор параметров
+type TunerConfig struct {
+	Method     TuningMethod
+	Base       Params        // Неподбираемые параметры: модель, операторы, число поколений и т.д.
+	Space      TuningSpace   // Подбираемые параметры (нулевое значение — DefaultTuningSpace(Base))
+	Graphs     []TuningGraph // Обучающие графы
+	Seeds      []int64       // Начальные значения генератора движка; каждый граф запускается с каждым (nil — {1})
+	Seed       int64         // Начальное значение генератора конфигураций (0 — по времени)
+	Candidates int           // Число конфигураций; для гонки — начальное (0 — 16)
+
+	// OnRun вызывается после каждого запуска ГА с числом выполненных запусков
+	OnRun func(runs int)
+	// Stop прерывает подбор после текущего запуска; отчёт строится по уже выполненным запускам
+	Stop <-chan struct{}
+}
+
+// ------------------------ Результаты ------------------------ //
+
+// TuningRun — запуск конфигурации на экземпляре
+type TuningRun struct {
+	Graph   string
+	Seed    int64
+	Fitness int // Приспособленность лучшего найденного решения
+	Score   float64
+	Time    time.Duration
+
+	instance int // Индекс экземпляра в tuner.instances
+}
+
+// TuningCandidate — оценённая конфигурация
+type TuningCandidate struct {
+	Params Params
+	Runs   []TuningRun
+	Score  float64 // Средняя оценка запусков (1 — оптимум на всех экземплярах)
+	Rounds int     // Пройдено раундов гонки (1 для других методов)
+	Err    error   // Ошибка проверки параметров; такая конфигурация ранжируется последней
+
+	point []float64 // Координаты в единичном кубе подбираемых параметров
+}
+
+// TuningReport — итог подбора
+type TuningReport struct {
+	Method    TuningMethod
+	Seed      int64             // Начальное значение генератора конфигураций, воспроизводящее подбор
+	Best      Params            // Параметры лучшей конфигурации
+	Ranking   []TuningCandidate // Конфигурации от лучшей к худшей
+	Runs      int
+	Instances int
+	Elapsed   time.Duration
+}
+
+// ------------------------ Подбор ------------------------ //
+
+// tuningInstance — обучающий экземпляр
+type tuningInstance struct {
+	graph   *TuningGraph
+	seed    int64
+	optimum *int // Приспособленность оптимума графа (-1 — неизвестна); общая для всех seed графа
+	best    *int // Лучшая приспособленность на графе среди запусков подбора
+}
+
+type tuner struct {
+	cfg        TunerConfig
+	dims       []tuningDim
+	instances  []tuningInstance
+	candidates []*TuningCandidate
+	rng        *rand.Rand
+	runs       int
+}
+
+// Tune подбирает параметры и возвращает отчёт с ранжированием конфигураций.
+// Перед каждым запуском генератор движка пересевается seed экземпляра, поэтому конфигурации
+// сравниваются на одних и тех же экземплярах, а отчёт воспроизводится по Seed и Seeds,
+// если одновременно с подбором не работают другие алгоритмы (генератор движка общий).
+// Использованный Seed записывается в отчёт.
+func Tune(cfg TunerConfig) (TuningReport, error) {
+	if len(cfg.Graphs) == 0 {
+		return TuningReport{}, errors.New("tuning needs at least one training graph")
+	}
+	if cfg.Candidates < 0 {
+		return TuningReport{}, fmt.Errorf("number of candidates must not be negative, got %d", cfg.Candidates)
+	}
+	if cfg.Space == (TuningSpace{}) {
+		cfg.Space = DefaultTuningSpace(cfg.Base)
+	}
+	if err := cfg.Space.Validate(); err != nil {
+		return TuningReport{}, err
+	}
+	seeds := cfg.Seeds
+	if len(seeds) == 0 {
+		seeds = []int64{1}
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	candidates := cfg.Candidates
+	if candidates == 0 {
+		candidates = 16
+	}
+
+	t := &tuner{cfg: cfg, dims: cfg.Space.dims(), rng: rand.New(rand.NewSource(seed))}
+	for i := range cfg.Graphs {
+		optimum, best := new(int), new(int)
+		*optimum = -1
+		for _, s := range seeds {
+			t.instances = append(t.instances, tuningInstance{graph: &cfg.Graphs[i], seed: s, optimum: optimum, best: best})
+		}
+	}
+
+	start := time.Now()
+	switch cfg.Method {
+	case TuneRacing:
+		t.race(candidates)
+	case TuneBayesian:
+		t.bayesian(candidates)
+	default:
+		for i := 0; i < candidates; i++ {
+			t.evaluateAll(t.newCandidate(t.randomPoint()))
+		}
+	}
+
+	report := TuningReport{Method: cfg.Method, Seed: seed, Runs: t.runs, Instances: len(t.instances), Elapsed: time.Since(start)}
+	for _, c := range t.candidates {
+		c.Score = t.score(c)
+		report.Ranking = append(report.Ranking, *c)
+	}
+	sort.SliceStable(report.Ranking, func(i, j int) bool {
+		a, b := report.Ranking[i], report.Ranking[j]
+		if (a.Err == nil) != (b.Err == nil) {
+			return a.Err == nil
+		}
+		// Выбывшие в гонке и прерванные конфигурации оценены на меньшем числе экземпляров
+		if a.Rounds != b.Rounds {
+			return a.Rounds > b.Rounds
+		}
+		if len(a.Runs) != len(b.Runs) {
+			return len(a.Runs) > len(b.Runs)
+		}
+		return a.Score > b.Score
+	})
+	if len(report.Ranking) == 0 || report.Ranking[0].Err != nil || len(report.Ranking[0].Runs) == 0 {
+		return report, errors.New("no candidate configuration completed a run")
+	}
+	report.Best = report.Ranking[0].Params
+	return report, nil
+}
+
+func (t *tuner) stopped() bool {
+	select {
+	case <-t.cfg.Stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *tuner) randomPoint() []float64 {
+	u := make([]float64, len(t.dims))
+	for i := range u {
+		u[i] = t.rng.Float64()
+	}
+	return u
+}
+
+// newCandidate создаёт конфигурацию из точки единичного куба
+func (t *tuner) newCandidate(u []float64) *TuningCandidate {
+	p := t.cfg.Base
+	// Затравочные решения относятся к графу интерфейса, а не к обучающим графам
+	p.Initialization.Seeds = nil
+	for i, apply := range t.dims {
+		apply(&p, u[i])
+	}
+	c := &TuningCandidate{Params: p, Rounds: 1, point: u}
+	t.candidates = append(t.candidates, c)
+	return c
+}
+
+// evaluateAll запускает конфигурацию на всех экземплярах
+func (t *tuner) evaluateAll(c *TuningCandidate) {
+	for i := range t.instances {
+		t.run(c, i)
+	}
+}
+
+// run запускает ГА с параметрами кандидата на экземпляре
+func (t *tuner) run(c *TuningCandidate, idx int) {
+	inst := &t.instances[idx]
+	if c.Err != nil || t.stopped() {
+		return
+	}
+	start := time.Now()
+	graph := inst.graph.Graph
+	genetic.Seed(inst.seed)
+	ga, err := genetic.New(&graph, c.Params.Options()...)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	*inst.optimum = ga.OptimalSize()
+
+	ga.InitializePopulation()
+	if err := runGenerations(ga, *inst.optimum, nil); err != nil {
+		c.Err = err
+		return
+	}
+
+	fitness := ga.GetBestSoFar().Fitness
+	*inst.best = max(*inst.best, fitness)
+	c.Runs = append(c.Runs, TuningRun{Graph: inst.graph.Name, Seed: inst.seed, Fitness: fitness, Time: time.Since(start), instance: idx})
+	t.runs++
+	if t.cfg.OnRun != nil {
+		t.cfg.OnRun(t.runs)
+	}
+}
+
+// score пересчитывает оценки запусков кандидата и возвращает среднюю;
+// без запусков или при ошибке оценка равна 0
+func (t *tuner) score(c *TuningCandidate) float64 {
+	if c.Err != nil || len(c.Runs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i, run := range c.Runs {
+		inst := t.instances[run.instance]
+		ref := *inst.best
+		if *inst.optimum > 0 {
+			ref = *inst.optimum
+		}
+		c.Runs[i].Score = float64(run.Fitness) / float64(max(ref, 1))
+		sum += c.Runs[i].Score
+	}
+	return sum / float64(len(c.Runs))
+}
+
+// race проводит гонку: в каждом раунде оставшиеся конфигурации запускаются на очередных
+// экземплярах, затем худшая половина по средней оценке выбывает. Когда экземпляры
+// заканчиваются, они используются повторно как новые повторы.
+func (t *tuner) race(n int) {
+	alive := make([]*TuningCandidate, n)
+	for i := range alive {
+		alive[i] = t.newCandidate(t.randomPoint())
+	}
+	order := t.rng.Perm(len(t.instances))
+	rounds := max(int(math.Ceil(math.Log2(float64(n)))), 1)
+	step := max((len(order)+rounds-1)/rounds, 1)
+
+	next := 0
+	for round := 1; !t.stopped(); round++ {
+		for _, c := range alive {
+			c.Rounds = round
+			for k := 0; k < step; k++ {
+				t.run(c, order[(next+k)%len(order)])
+			}
+		}
+		next += step
+		if len(alive) == 1 {
+			break
+		}
+		scores := make(map[*TuningCandidate]float64, len(alive))
+		for _, c := range alive {
+			scores[c] = t.score(c)
+		}
+		sort.SliceStable(alive, func(i, j int) bool {
+			if (alive[i].Err == nil) != (alive[j].Err == nil) {
+				return alive[i].Err == nil
+			}
+			return scores[alive[i]] > scores[alive[j]]
+		})
+		alive = alive[:(len(alive)+1)/2]
+	}
+}
+
+// bayesian оценивает n конфигураций: первые четверть — случайные, остальные выбираются
+// по наибольшему ожидаемому улучшению гауссовского процесса, обученного на оценках
+func (t *tuner) bayesian(n int) {
+	initial := min(max(n/4, 2), n)
+	for i := 0; i < n && !t.stopped(); i++ {
+		point := t.randomPoint()
+		if i >= initial && len(t.dims) > 0 {
+			var xs [][]float64
+			var ys []float64
+			for _, c := range t.candidates {
+				xs = append(xs, c.point)
+				ys = append(ys, t.score(c))
+			}
+			point = t.propose(newGaussianProcess(xs, ys), ys)
+		}
+		t.evaluateAll(t.newCandidate(point))
+	}
+}
+
+// propose выбирает точку с наибольшим ожидаемым улучшением среди случайных точек
+// и возмущений лучшей оценённой
+func (t *tuner) propose(gp *gaussianProcess, ys []float64) []float64 {
+	bestIdx := 0
+	for i, y := range ys {
+		if y > ys[bestIdx] {
+			bestIdx = i
+		}
+	}
+	target := ys[bestIdx]
+	incumbent := t.candidates[bestIdx].point
+
+	var best []float64
+	bestEI := -1.0
+	for k := 0; k < 512; k++ {
+		var u []float64
+		if k%4 == 0 {
+			u = make([]float64, len(incumbent))
+			for i, x := range incumbent {
+				u[i] = math.Min(math.Max(x+0.1*t.rng.NormFloat64(), 0), 1)
+			}
+		} else {
+			u = t.randomPoint()
+		}
+		if ei := gp.expectedImprovement(u, target); ei > bestEI {
+			best, bestEI = u, ei
+		}
+	}
+	return best
+}
+
+// ------------------------ Гауссовский процесс ------------------------ //
+
+// gaussianProcess — регрессия с RBF-ядром на стандартизованных оценках
+type gaussianProcess struct {
+	xs          [][]float64
+	chol        [][]float64 // Нижний треугольный множитель Холецкого матрицы ядра
+	alpha       []float64   // K⁻¹ y
+	lengthScale float64
+	mean, std   float64
+}
+
+// gpNoise — дисперсия шума наблюдений: оценка конфигурации случайна
+const gpNoise = 0.05
+
+func newGaussianProcess(xs [][]float64, ys []float64) *gaussianProcess {
+	gp := &gaussianProcess{xs: xs, lengthScale: 0.25 * math.Sqrt(float64(len(xs[0])))}
+	for _, y := range ys {
+		gp.mean += y
+	}
+	gp.mean /= float64(len(ys))
+	for _, y := range ys {
+		gp.std += (y - gp.mean) * (y - gp.mean)
+	}
+	gp.std = math.Sqrt(gp.std / float64(len(ys)))
+	if gp.std == 0 {
+		gp.std = 1
+	}
+
+	n := len(xs)
+	k := make([][]float64, n)
+	for i := range k {
+		k[i] = make([]float64, n)
+		for j := range k[i] {
+			k[i][j] = gp.kernel(xs[i], xs[j])
+		}
+		k[i][i] += gpNoise
+	}
+	gp.chol = cholesky(k)
+	z := make([]float64, n)
+	for i, y := range ys {
+		z[i] = (y - gp.mean) / gp.std
+	}
+	gp.alpha = solveUpper(gp.chol, solveLower(gp.chol, z))
+	return gp
+}
+
+func (gp *gaussianProcess) kernel(a, b []float64) float64 {
+	d := 0.0
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Exp(-d / (2 * gp.lengthScale * gp.lengthScale))
+}
+
+// predict возвращает среднее и стандартное отклонение оценки в точке u
+func (gp *gaussianProcess) predict(u []float64) (mu, sigma float64) {
+	ks := make([]float64, len(gp.xs))
+	for i, x := range gp.xs {
+		ks[i] = gp.kernel(u, x)
+		mu += ks[i] * gp.alpha[i]
+	}
+	v := solveLower(gp.chol, ks)
+	variance := 1.0
+	for _, x := range v {
+		variance -= x * x
+	}
+	return gp.mean + gp.std*mu, gp.std * math.Sqrt(math.Max(variance, 1e-12))
+}
+
+// expectedImprovement возвращает ожидаемое превышение target в точке u
+func (gp *gaussianProcess) expectedImprovement(u []float64, target float64) float64 {
+	mu, sigma := gp.predict(u)
+	z := (mu - target) / sigma
+	cdf := 0.5 * (1 + math.Erf(z/math.Sqrt2))
+	pdf := math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+	return (mu-target)*cdf + sigma*pdf
+}
+
+// cholesky раскладывает симметричную положительно определённую матрицу a = L·Lᵀ
+func cholesky(a [][]float64) [][]float64 {
+	n := len(a)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				l[i][i] = math.Sqrt(math.Max(sum, 1e-12))
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l
+}
+
+// solveLower решает L·x = b
+func solveLower(l [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := range b {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
+
+// solveUpper решает Lᵀ·x = b
+func solveUpper(l [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := len(b) - 1; i >= 0; i-- {
+		sum := b[i]
+		for k := i + 1; k < len(b); k++ {
+			sum -= l[k][i] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
+
+// ------------------------ Отчёт ------------------------ //
+
+// Write выводит ранжирование конфигураций таблицей
+func (r TuningReport) Write(w io.Writer) error {
+	fmt.Fprintf(w, "Tuning: method=%v, seed=%d, runs=%d, instances=%d, time=%v\n",
+		r.Method, r.Seed, r.Runs, r.Instances, r.Elapsed.Round(time.Millisecond))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tScore\tRuns\tRounds\tPop\tMutation\tCrossover\tTournament\tIslands\tMigration\tTime/run\tError")
+	for i, c := range r.Ranking {
+		p := c.Params
+		perRun := time.Duration(0)
+		for _, run := range c.Runs {
+			perRun += run.Time
+		}
+		if len(c.Runs) > 0 {
+			perRun /= time.Duration(len(c.Runs))
+		}
+		errText := "-"
+		if c.Err != nil {
+			errText = c.Err.Error()
+		}
+		fmt.Fprintf(tw, "%d\t%.4f\t%d\t%d\t%d\t%.4f\t%.3f\t%d\t%d\t%d\t%v\t%s\n",
+			i+1, c.Score, len(c.Runs), c.Rounds, p.PopulationSize, p.MutationRate, p.CrossoverRate,
+			p.TournamentSize, p.NumIslands, p.MigrationInterval, perRun.Round(time.Millisecond), errText)
+	}
+	return tw.Flush()
+}
+
+func (r TuningReport) String() string {
+	var sb strings.Builder
+	r.Write(&sb)
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+// islandnode запускает узел распределённой островной модели или координатор.
+//
+// Пример: координатор и три узла по кольцу на одной машине
+//
+//	islandnode -mode coordinator -listen 127.0.0.1:7000 -nodes 3
+//	islandnode -id 0 -listen 127.0.0.1:7001 -peers 127.0.0.1:7002 -coordinator 127.0.0.1:7000
+//	islandnode -id 1 -listen 127.0.0.1:7002 -peers 127.0.0.1:7003 -coordinator 127.0.0.1:7000
+//	islandnode -id 2 -listen 127.0.0.1:7003 -peers 127.0.0.1:7001 -coordinator 127.0.0.1:7000
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"Genetic-algorithm/backend/distributed"
+	"Genetic-algorithm/backend/genetic"
+)
+
+func main() {
+	mode := flag.String("mode", "node", "режим: node или coordinator")
+	id := flag.Int("id", 0, "номер узла")
+	listen := flag.String("listen", "127.0.0.1:7001", "адрес для входящих соединений")
+	peers := flag.String("peers", "", "адреса соседей через запятую")
+	coordinator := flag.String("coordinator", "", "адрес координатора")
+	nodes := flag.Int("nodes", 1, "число узлов, ожидаемых координатором")
+	graphName := flag.String("graph", "Dodecahedron (20)", "имя предопределённого графа")
+	pop := flag.Int("pop", 50, "размер популяции узла")
+	gens := flag.Int("gens", 200, "число поколений")
+	interval := flag.Int("interval", 10, "интервал миграции (поколения)")
+	migrants := flag.Int("migrants", 2, "число мигрантов на соседа")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *mode == "coordinator" {
+		runCoordinator(ctx, *listen, *nodes)
+		return
+	}
+
+	gm, ok := genetic.PredefinedGraphs()[*graphName]
+	if !ok {
+		log.Fatalf("unknown graph %q, available: %s", *graphName, strings.Join(graphNames(), ", "))
+	}
+	graph := gm.ToGraph()
+
+	ga, err := genetic.NewGeneticAlgorithm(
+		&graph,
+		genetic.Classic,
+		&genetic.SinglePoint{},
+		&genetic.TournamentSelectionStrategy{TournamentSize: 3},
+		&genetic.ClassicMutationStrategy{},
+		*pop, *gens, 0.05, 0.9, 1, *interval,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	node, err := distributed.NewNode(distributed.NodeConfig{
+		ID:                *id,
+		ListenAddr:        *listen,
+		Neighbors:         splitList(*peers),
+		CoordinatorAddr:   *coordinator,
+		MigrationInterval: *interval,
+		Migration:         genetic.MigrationPolicy{Migrants: *migrants},
+	}, ga)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	best, err := node.Run(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	accepted, rejected := node.Migrants()
+	fmt.Printf("node %d finished: best fitness %d, migrants accepted %d, rejected %d\n", *id, best.Fitness, accepted, rejected)
+}
+
+func runCoordinator(ctx context.Context, listen string, nodes int) {
+	c := distributed.NewCoordinator(listen, nodes)
+	c.OnImprovement = func(st distributed.NodeStatus) {
+		fmt.Printf("global best %d from node %d (generation %d)\n", st.Best.Fitness, st.Node, st.Generation)
+	}
+	if err := c.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	statuses := c.Statuses()
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Node < statuses[j].Node })
+	for _, st := range statuses {
+		fmt.Printf("node %d: generation %d, best %d, done %v\n", st.Node, st.Generation, st.Best.Fitness, st.Done)
+	}
+	if best, ok := c.Best(); ok {
+		fmt.Printf("global best: %d (node %d)\n", best.Best.Fitness, best.Node)
+	}
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func graphNames() []string {
+	var names []string
+	for name := range genetic.PredefinedGraphs() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -4,6 +4,7 @@ import (
 	"Genetic-algorithm/backend"
 	"Genetic-algorithm/backend/genetic"
 	"errors"
+	"fmt"
 	"log"
 	"sort"
 
@@ -20,6 +21,9 @@ type MainWindow struct {
 	Controls     *ControlsPanel
 	Solver       *backend.GASolver
 	PresetSelect *widget.Select // Добавляем сохранение селектора
+
+	problem  genetic.ProblemType // Задача последнего запуска (определяет раскраску графа)
+	tuneStop chan struct{}       // Закрывается кнопкой остановки подбора параметров
 }
 
 func NewMainWindow(app fyne.App) *MainWindow {
@@ -39,6 +43,10 @@ func NewMainWindow(app fyne.App) *MainWindow {
 	presetSelect := widget.NewSelect(names, func(name string) {
 		gm := predefs[name]
 		graphWidget.SetGraphModel(gm)
+		// Затравочные паросочетания относятся к прежнему графу
+		controls.SetSeeds(nil)
+		controls.SetCapacities(gm.Capacities)
+		controls.SetPreferences(gm.Preferences)
 	})
 	presetSelect.PlaceHolder = "Select graph..."
 
@@ -90,7 +98,36 @@ func (mw *MainWindow) setupCallbacks() {
 		}
 
 		params := mw.Controls.GetParams()
-		graph := genetic.Graph{NumVertices: gm.NumVertices, Edges: gm.Edges}
+		graph := gm.ToGraph()
+		graph.RequirePerfect = mw.Controls.RequirePerfect.Checked
+		settings := genetic.NewSettings(params.Options()...)
+		err := settings.Validate(params.Problem.GenomeLength(&graph))
+		if err == nil {
+			err = settings.ValidateGraph(&graph)
+		}
+		if err != nil {
+			dialog.ShowError(err, mw.Window)
+			mw.Controls.StartBtn.Enable()
+			mw.Controls.StopBtn.Disable()
+			return
+		}
+		// Взвешенный граф проверяется заранее: он должен быть двудольным и, если требуется, иметь совершенное паросочетание.
+		// Другие задачи не допускают стоимостей и ёмкостей.
+		if params.Problem != genetic.Matching {
+			_, err = genetic.NewProblem(params.Problem, &graph, nil)
+		} else if graph.Costs != nil {
+			_, err = genetic.SolveAssignment(&graph)
+		}
+		if err == nil && params.EvolutionModel == genetic.NSGA2 {
+			err = params.MultiObjective.Validate(&graph)
+		}
+		if err != nil {
+			dialog.ShowError(err, mw.Window)
+			mw.Controls.StartBtn.Enable()
+			mw.Controls.StopBtn.Disable()
+			return
+		}
+		mw.problem = params.Problem
 
 		// Получаем имя текущего графа
 		graphName := "Custom"
@@ -100,6 +137,16 @@ func (mw *MainWindow) setupCallbacks() {
 
 		mw.Solver.UpdateChan = make(chan genetic.Chromosome)
 		mw.Solver.Done = make(chan struct{})
+		mw.Solver.OnIslandStats = mw.Controls.SetIslandStats
+		mw.Solver.OnParetoFront = mw.Controls.SetParetoFront
+		mw.Solver.OnCellularGrid = mw.Controls.CellularGrid.SetGrid
+		mw.GraphWidget.updateEdgeWidths(nil)
+		mw.Solver.OnGeneProbabilities = func(probs []float64) {
+			if !mw.problem.EncodesVertices() {
+				mw.GraphWidget.updateEdgeWidths(probs)
+			}
+		}
+		mw.Solver.OnPheromone = mw.GraphWidget.updateEdgeWidths
 
 		// Передаем три аргумента
 		mw.Solver.Start(graph, params, graphName)
@@ -120,11 +167,20 @@ func (mw *MainWindow) setupCallbacks() {
 						bestRes = r
 					}
 				}
-				bestIndices := make(map[int]struct{})
-				for _, idx := range bestRes.BestMatchingEdges {
-					bestIndices[idx] = struct{}{}
+				last := mw.Solver.Results[len(mw.Solver.Results)-1]
+				if mw.problem == genetic.Matching {
+					bestIndices := make(map[int]struct{})
+					for _, idx := range bestRes.BestMatchingEdges {
+						bestIndices[idx] = struct{}{}
+					}
+					mw.GraphWidget.updateEdgeColorsBestOnly(bestIndices)
+				} else {
+					mw.updateGraph(genetic.Chromosome{Genes: last.BestChromosomeGenes})
 				}
-				mw.GraphWidget.updateEdgeColorsBestOnly(bestIndices)
+				mw.Controls.SetAssignmentResult(last)
+				mw.Controls.SetProblemResult(last)
+				mw.Controls.SetRateHistory(last.RateHistory)
+				mw.Controls.SetRestartEvents(last.RestartEvents)
 			}
 			mw.Controls.StartBtn.Enable()
 			mw.Controls.StopBtn.Disable()
@@ -141,6 +197,195 @@ func (mw *MainWindow) setupCallbacks() {
 		mw.Window.Canvas().Refresh(mw.Controls.StopBtn)
 	}
 
+	mw.Controls.OnLoadMatching = func() {
+		dialog.ShowFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil || r == nil {
+				return
+			}
+			defer r.Close()
+			graph := mw.currentGraph()
+			genes, err := genetic.LoadMatching(r.URI().Path(), &graph)
+			if err != nil {
+				dialog.ShowError(err, mw.Window)
+				return
+			}
+			mw.Controls.SetSeeds(append(mw.Controls.Seeds, genes))
+		}, mw.Window)
+	}
+
+	mw.Controls.OnSaveMatching = func() {
+		genes := mw.bestGenes()
+		if genes == nil {
+			dialog.ShowError(errors.New("нет найденного паросочетания"), mw.Window)
+			return
+		}
+		dialog.ShowFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err != nil || w == nil {
+				return
+			}
+			w.Close()
+			graph := mw.currentGraph()
+			if err := genetic.SaveMatching(w.URI().Path(), &graph, genes); err != nil {
+				dialog.ShowError(err, mw.Window)
+			}
+		}, mw.Window)
+	}
+
+	mw.Controls.OnSeedFromBest = func() {
+		genes := mw.bestGenes()
+		if genes == nil {
+			dialog.ShowError(errors.New("нет найденного паросочетания"), mw.Window)
+			return
+		}
+		mw.Controls.SetSeeds(append(mw.Controls.Seeds, genes))
+	}
+
+	mw.Controls.OnLoadCosts = func() {
+		dialog.ShowFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil || r == nil {
+				return
+			}
+			defer r.Close()
+			costs, err := genetic.LoadCostMatrix(r.URI().Path())
+			if err != nil {
+				dialog.ShowError(err, mw.Window)
+				return
+			}
+			gm, err := genetic.NewAssignmentModel(costs)
+			if err != nil {
+				dialog.ShowError(err, mw.Window)
+				return
+			}
+			mw.GraphWidget.SetGraphModel(gm)
+			// Граф больше не совпадает с шаблоном; OnChanged не вызываем
+			mw.PresetSelect.Selected = ""
+			mw.PresetSelect.Refresh()
+			mw.Controls.SetSeeds(nil)
+			mw.Controls.SetPreferences(nil)
+			mw.Controls.AssignmentLabel.SetText(fmt.Sprintf("Cost matrix %dx%d loaded", len(costs), len(costs[0])))
+		}, mw.Window)
+	}
+
+	mw.Controls.OnCapacities = func() {
+		gm := mw.GraphWidget.GetGraphModel()
+		caps, err := mw.Controls.Capacities(gm.NumVertices)
+		if err != nil {
+			dialog.ShowError(err, mw.Window)
+			return
+		}
+		gm.Capacities = caps
+		mw.GraphWidget.SetGraphModel(gm)
+		// Паросочетание прежнего графа может нарушать новые ёмкости
+		mw.Controls.SetSeeds(nil)
+	}
+
+	mw.Controls.OnRandomPrefs = func() {
+		gm := mw.GraphWidget.GetGraphModel()
+		gm.RandomizePreferences()
+		mw.Controls.SetPreferences(gm.Preferences)
+	}
+
+	mw.Controls.OnTune = func() {
+		graphName := "Custom"
+		if mw.PresetSelect.Selected != "" {
+			graphName = mw.PresetSelect.Selected
+		}
+		graph := mw.currentGraph()
+		graph.RequirePerfect = mw.Controls.RequirePerfect.Checked
+		if len(graph.Edges) == 0 {
+			dialog.ShowError(errors.New("граф не содержит рёбер"), mw.Window)
+			return
+		}
+		tc := mw.Controls.Tuner()
+		tc.Graphs = []backend.TuningGraph{{Name: graphName, Graph: graph}}
+		predefs := genetic.PredefinedGraphs()
+		for _, name := range mw.Controls.TuningGraphs.Selected {
+			if name != graphName {
+				tc.Graphs = append(tc.Graphs, backend.TuningGraph{Name: name, Graph: predefs[name].ToGraph()})
+			}
+		}
+		mw.tuneStop = make(chan struct{})
+		tc.Stop = mw.tuneStop
+		tc.OnRun = func(runs int) {
+			mw.Controls.TuningLabel.SetText(fmt.Sprintf("Tuning: %d runs done", runs))
+		}
+
+		mw.Controls.TuneBtn.Disable()
+		mw.Controls.StopTuneBtn.Enable()
+		mw.Controls.StartBtn.Disable()
+		go func() {
+			report, err := backend.Tune(tc)
+			mw.Controls.TuneBtn.Enable()
+			mw.Controls.StopTuneBtn.Disable()
+			mw.Controls.StartBtn.Enable()
+			if err != nil {
+				mw.Controls.TuningLabel.SetText("Tuning failed")
+				dialog.ShowError(err, mw.Window)
+				return
+			}
+			mw.Controls.ApplyTuned(report.Best)
+			best := report.Ranking[0]
+			mw.Controls.TuningLabel.SetText(fmt.Sprintf("Best score %.4f over %d runs (%d total), applied", best.Score, len(best.Runs), report.Runs))
+			text := widget.NewLabel(report.String())
+			text.TextStyle = fyne.TextStyle{Monospace: true}
+			scroll := container.NewScroll(text)
+			scroll.SetMinSize(fyne.NewSize(900, 400))
+			dialog.ShowCustom("Tuning report", "Close", scroll, mw.Window)
+		}()
+	}
+
+	mw.Controls.OnStopTuning = func() {
+		if mw.tuneStop != nil {
+			close(mw.tuneStop)
+			mw.tuneStop = nil
+		}
+	}
+
+	mw.Controls.OnLandscape = func() {
+		graphName := "Custom"
+		if mw.PresetSelect.Selected != "" {
+			graphName = mw.PresetSelect.Selected
+		}
+		graph := mw.currentGraph()
+		if len(graph.Edges) == 0 {
+			dialog.ShowError(errors.New("граф не содержит рёбер"), mw.Window)
+			return
+		}
+		cfg := mw.Controls.Landscape()
+		if err := cfg.Validate(); err != nil {
+			dialog.ShowError(err, mw.Window)
+			return
+		}
+
+		mw.Controls.LandscapeBtn.Disable()
+		mw.Controls.LandscapeLabel.SetText("Analysing...")
+		go func() {
+			defer mw.Controls.LandscapeBtn.Enable()
+			report, err := genetic.AnalyzeLandscape(&graph, cfg)
+			if err != nil {
+				mw.Controls.LandscapeLabel.SetText("Analysis failed")
+				dialog.ShowError(err, mw.Window)
+				return
+			}
+			summary := fmt.Sprintf("FDC %.3f, neutrality %.3f", report.FDC.Correlation, report.Neutrality.Ratio)
+			if report.LocalOptima.Computed {
+				summary += fmt.Sprintf(", %d local optima (%d global) of %d solutions",
+					report.LocalOptima.LocalOptima, report.LocalOptima.GlobalOptima, report.LocalOptima.Solutions)
+			}
+			mw.Controls.LandscapeLabel.SetText(summary)
+			dir, err := backend.PlotLandscape(report, graphName)
+			if err != nil {
+				dialog.ShowError(err, mw.Window)
+				return
+			}
+			dialog.ShowInformation(
+				"Ландшафт проанализирован",
+				summary+"\nОтчёт и графики сохранены в папку "+dir,
+				mw.Window,
+			)
+		}()
+	}
+
 	mw.Controls.OnPlot = func() {
 		if len(mw.Solver.Results) == 0 {
 			dialog.ShowError(errors.New("нет данных для построения графиков"), mw.Window)
@@ -160,3 +405,21 @@ func (mw *MainWindow) setupCallbacks() {
 		}()
 	}
 }
+
+// currentGraph возвращает граф, отображаемый в GraphWidget
+func (mw *MainWindow) currentGraph() genetic.Graph {
+	return mw.GraphWidget.GetGraphModel().ToGraph()
+}
+
+// bestGenes возвращает геном лучшего результата для текущего графа или nil
+func (mw *MainWindow) bestGenes() []bool {
+	edges := len(mw.GraphWidget.GetGraphModel().Edges)
+	var best []bool
+	bestFitness := -1
+	for _, r := range mw.Solver.Results {
+		if len(r.BestChromosomeGenes) == edges && r.BestFitness > bestFitness {
+			best, bestFitness = r.BestChromosomeGenes, r.BestFitness
+		}
+	}
+	return best
+}
@@ -0,0 +1,77 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Размеры решётки клеточной модели
+const (
+	cellularWidth  = 280
+	cellularHeight = 220
+)
+
+// CellularGridView рисует решётку клеточной модели, раскрашивая клетки по приспособленности:
+// от синего (худшая особь) к красному (лучшая)
+type CellularGridView struct {
+	widget.BaseWidget
+	container *fyne.Container
+}
+
+func NewCellularGridView() *CellularGridView {
+	cv := &CellularGridView{container: container.NewWithoutLayout()}
+	cv.ExtendBaseWidget(cv)
+	cv.SetGrid(0, 0, nil)
+	return cv
+}
+
+func (cv *CellularGridView) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(cv.container)
+}
+
+func (cv *CellularGridView) MinSize() fyne.Size {
+	return fyne.NewSize(cellularWidth, cellularHeight)
+}
+
+// SetGrid перерисовывает решётку width×height; fitness перечисляет клетки по строкам
+func (cv *CellularGridView) SetGrid(width, height int, fitness []int) {
+	textColor := color.NRGBA{R: 180, G: 180, B: 180, A: 255}
+	if width == 0 || height == 0 || len(fitness) != width*height {
+		empty := canvas.NewText("No cellular grid yet", textColor)
+		empty.TextSize = 12
+		empty.Move(fyne.NewPos(10, cellularHeight/2))
+		cv.container.Objects = []fyne.CanvasObject{empty}
+		cv.container.Refresh()
+		return
+	}
+
+	lo, hi := fitness[0], fitness[0]
+	for _, f := range fitness {
+		lo, hi = min(lo, f), max(hi, f)
+	}
+	cell := min(float32(cellularWidth)/float32(width), float32(cellularHeight-16)/float32(height))
+
+	objects := make([]fyne.CanvasObject, 0, len(fitness)+1)
+	for i, f := range fitness {
+		t := float32(0.5)
+		if hi > lo {
+			t = float32(f-lo) / float32(hi-lo)
+		}
+		rect := canvas.NewRectangle(color.NRGBA{R: uint8(50 + 205*t), G: uint8(80 + 70*(1-t)), B: uint8(250 * (1 - t)), A: 255})
+		rect.Resize(fyne.NewSize(cell, cell))
+		rect.Move(fyne.NewPos(float32(i%width)*cell, float32(i/width)*cell))
+		objects = append(objects, rect)
+	}
+	legend := canvas.NewText(fmt.Sprintf("%dx%d, fitness %d..%d", width, height, lo, hi), textColor)
+	legend.TextSize = 10
+	legend.Move(fyne.NewPos(0, float32(height)*cell+2))
+	objects = append(objects, legend)
+
+	cv.container.Objects = objects
+	cv.container.Refresh()
+}
@@ -3,7 +3,10 @@ package frontend
 import (
 	"Genetic-algorithm/backend"
 	"Genetic-algorithm/backend/genetic"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -21,15 +24,126 @@ type ControlsPanel struct {
 	CrossoverRate     *widget.Entry
 	NumIslands        *widget.Entry
 	MigrationInterval *widget.Entry
+	Topology          *widget.RadioGroup
+	Migrants          *widget.Entry
+	Emigrants         *widget.RadioGroup
+	Immigrants        *widget.RadioGroup
+	AsyncMigration    *widget.Check
+	Heterogeneous     *widget.Check
+	IslandStats       *widget.Label
 	EvolutionModel    *widget.RadioGroup
+	Problem           *widget.RadioGroup
+	ProblemLabel      *widget.Label
 
 	CrossoverType  *widget.RadioGroup
+	CrossoverAdapt *widget.RadioGroup
 	MutationType   *widget.RadioGroup
 	SelectionType  *widget.RadioGroup
 	TournamentSize *widget.Entry
+
+	SharingRadius  *widget.Entry
+	Replacement    *widget.RadioGroup
+	RTRWindow      *widget.Entry
+	EliminateDupes *widget.Check
+
+	Repair       *widget.RadioGroup
+	GreedyExtend *widget.Check
+	CacheFitness *widget.Check
+	CacheSize    *widget.Entry
+
+	LocalSearch         *widget.RadioGroup
+	Learning            *widget.RadioGroup
+	LocalSearchFraction *widget.Entry
+
+	InitRandom    *widget.Entry
+	InitSparse    *widget.Entry
+	SparseDensity *widget.Entry
+	InitGreedy    *widget.Entry
+	InitMinDegree *widget.Entry
+	SeedFraction  *widget.Entry
+	SeedsLabel    *widget.Label
+	LoadSeedBtn   *widget.Button
+	SaveBestBtn   *widget.Button
+	SeedBestBtn   *widget.Button
+	Seeds         [][]bool // Затравочные паросочетания для текущего графа
+
+	RequirePerfect  *widget.Check
+	LoadCostsBtn    *widget.Button
+	AssignmentLabel *widget.Label
+
+	DefaultCapacity   *widget.Entry
+	CapacityOverrides *widget.Entry
+	ApplyCapacityBtn  *widget.Button
+
+	RandomPrefsBtn   *widget.Button
+	PreferencesLabel *widget.Label
+
+	PreferredVertices *widget.Entry
+	ParetoLabel       *widget.Label
+	ParetoPlot        *ParetoPlot
+
+	MutationSchedule   *widget.RadioGroup
+	FinalMutationRate  *widget.Entry
+	CrossoverSchedule  *widget.RadioGroup
+	FinalCrossoverRate *widget.Entry
+	RateAdaptation     *widget.RadioGroup
+	RatesLabel         *widget.Label
+
+	SteadyOffspring   *widget.Entry
+	SteadyReplacement *widget.RadioGroup
+
+	CellNeighbourhood *widget.RadioGroup
+	CellRadius        *widget.Entry
+	CellUpdate        *widget.RadioGroup
+	CellularGrid      *CellularGridView
+
+	EDAVariant        *widget.RadioGroup
+	EDALearningRate   *widget.Entry
+	EDASelectionRatio *widget.Entry
+
+	PheromoneUpdate *widget.RadioGroup
+	PheromoneAlpha  *widget.Entry
+	PheromoneBeta   *widget.Entry
+	Evaporation     *widget.Entry
+	AntLocalSearch  *widget.Check
+
+	InitialTemperature *widget.Entry
+	TabuTenure         *widget.Entry
+	TabuCandidates     *widget.Entry
+
+	StagnationResponse *widget.RadioGroup
+	StagnationWindow   *widget.Entry
+	HyperGenerations   *widget.Entry
+	ImmigrantFraction  *widget.Entry
+	RestartsLabel      *widget.Label
+
 	OnStart        func()
 	OnStop         func()
 	OnPlot         func()
+	OnLoadMatching func()
+	OnSaveMatching func()
+	OnSeedFromBest func()
+	OnLoadCosts    func()
+	OnCapacities   func()
+	OnRandomPrefs  func()
+	OnTune         func()
+	OnStopTuning   func()
+
+	TuningMethod     *widget.RadioGroup
+	TuningCandidates *widget.Entry
+	TuningSeeds      *widget.Entry
+	TuningSeed       *widget.Entry
+	TuningGraphs     *widget.CheckGroup
+	TuneBtn          *widget.Button
+	StopTuneBtn      *widget.Button
+	TuningLabel      *widget.Label
+
+	OnLandscape         func()
+	LandscapeSamples    *widget.Entry
+	LandscapeWalk       *widget.Entry
+	LandscapeExhaustive *widget.Entry
+	LandscapeBtn        *widget.Button
+	LandscapeLabel      *widget.Label
 }
 
 func NewControlsPanel() *ControlsPanel {
@@ -40,12 +154,103 @@ func NewControlsPanel() *ControlsPanel {
 		CrossoverRate:     widget.NewEntry(),
 		NumIslands:        widget.NewEntry(),
 		MigrationInterval: widget.NewEntry(),
-		EvolutionModel:    widget.NewRadioGroup([]string{"Classic", "Island", "Steady-State", "Memetic", "Combined"}, nil),
+		Topology:          widget.NewRadioGroup([]string{"Ring", "Fully Connected", "Star", "Random", "Hypercube"}, nil),
+		Migrants:          widget.NewEntry(),
+		Emigrants:         widget.NewRadioGroup([]string{"Best", "Random", "Diverse"}, nil),
+		Immigrants:        widget.NewRadioGroup([]string{"Replace Worst", "Replace Random"}, nil),
+		AsyncMigration:    widget.NewCheck("Asynchronous migration", nil),
+		Heterogeneous:     widget.NewCheck("Heterogeneous island operators", nil),
+		IslandStats:       widget.NewLabel("No island statistics yet"),
+		EvolutionModel:    widget.NewRadioGroup([]string{"Classic", "Island", "Steady-State", "Memetic", "Combined", "NSGA-II", "Cellular", "EDA", "Simulated Annealing", "Tabu Search", "Ant Colony"}, nil),
+		Problem:           widget.NewRadioGroup([]string{"Matching", "Independent Set", "Vertex Cover", "Max Cut", "Edge Dominating Set", "Stable Matching"}, nil),
+		ProblemLabel:      widget.NewLabel("No solution yet"),
 
 		CrossoverType:  widget.NewRadioGroup([]string{"Single-point", "Two-point", "Combined"}, nil),
+		CrossoverAdapt: widget.NewRadioGroup([]string{"Fixed", "Probability Matching", "Adaptive Pursuit"}, nil),
 		MutationType:   widget.NewRadioGroup([]string{"Classic", "Island", "Steady-State", "Conflict-Adaptive", "Augmenting-Path", "Combined"}, nil),
-		SelectionType:  widget.NewRadioGroup([]string{"Tournament", "Roulette", "Rank"}, nil),
+		SelectionType:  widget.NewRadioGroup([]string{"Tournament", "Roulette", "Rank", "SUS", "Boltzmann", "Truncation", "Linear Rank", "Exponential Rank", "Lexicase"}, nil),
 		TournamentSize: widget.NewEntry(),
+
+		SharingRadius:  widget.NewEntry(),
+		Replacement:    widget.NewRadioGroup([]string{"Generational", "Deterministic Crowding", "Restricted Tournament"}, nil),
+		RTRWindow:      widget.NewEntry(),
+		EliminateDupes: widget.NewCheck("Eliminate duplicates", nil),
+
+		Repair:       widget.NewRadioGroup([]string{"Index Order", "Random Order", "Degree-Aware"}, nil),
+		GreedyExtend: widget.NewCheck("Greedy extend after repair", nil),
+		CacheFitness: widget.NewCheck("Cache fitness (LRU)", nil),
+		CacheSize:    widget.NewEntry(),
+
+		LocalSearch:         widget.NewRadioGroup([]string{"Single Augmentation", "Repeated Augmentation", "2-opt", "Blossom", "Bounded Depth"}, nil),
+		Learning:            widget.NewRadioGroup([]string{"Lamarckian", "Baldwinian"}, nil),
+		LocalSearchFraction: widget.NewEntry(),
+
+		InitRandom:    widget.NewEntry(),
+		InitSparse:    widget.NewEntry(),
+		SparseDensity: widget.NewEntry(),
+		InitGreedy:    widget.NewEntry(),
+		InitMinDegree: widget.NewEntry(),
+		SeedFraction:  widget.NewEntry(),
+		SeedsLabel:    widget.NewLabel("No seed matchings"),
+
+		RequirePerfect:  widget.NewCheck("Require perfect matching", nil),
+		AssignmentLabel: widget.NewLabel("Unweighted graph"),
+
+		DefaultCapacity:   widget.NewEntry(),
+		CapacityOverrides: widget.NewEntry(),
+
+		PreferencesLabel: widget.NewLabel("No preference lists"),
+
+		PreferredVertices: widget.NewEntry(),
+		ParetoLabel:       widget.NewLabel("No Pareto front yet"),
+		ParetoPlot:        NewParetoPlot(),
+
+		MutationSchedule:   widget.NewRadioGroup(rateScheduleOptions, nil),
+		FinalMutationRate:  widget.NewEntry(),
+		CrossoverSchedule:  widget.NewRadioGroup(rateScheduleOptions, nil),
+		FinalCrossoverRate: widget.NewEntry(),
+		RateAdaptation:     widget.NewRadioGroup([]string{"None", "1/5 Success Rule", "Self-Adaptive"}, nil),
+		RatesLabel:         widget.NewLabel("Constant rates"),
+
+		SteadyOffspring:   widget.NewEntry(),
+		SteadyReplacement: widget.NewRadioGroup(steadyReplacementOptions, nil),
+
+		CellNeighbourhood: widget.NewRadioGroup([]string{"Von Neumann", "Moore"}, nil),
+		CellRadius:        widget.NewEntry(),
+		CellUpdate:        widget.NewRadioGroup([]string{"Synchronous", "Line Sweep", "Random Sweep"}, nil),
+		CellularGrid:      NewCellularGridView(),
+
+		EDAVariant:        widget.NewRadioGroup([]string{"PBIL", "UMDA", "Compact GA"}, nil),
+		EDALearningRate:   widget.NewEntry(),
+		EDASelectionRatio: widget.NewEntry(),
+
+		TuningMethod:     widget.NewRadioGroup([]string{"Random Search", "Racing", "Bayesian"}, nil),
+		TuningCandidates: widget.NewEntry(),
+		TuningSeeds:      widget.NewEntry(),
+		TuningSeed:       widget.NewEntry(),
+		TuningGraphs:     widget.NewCheckGroup(presetNames(), nil),
+		TuningLabel:      widget.NewLabel("Not tuned yet"),
+
+		LandscapeSamples:    widget.NewEntry(),
+		LandscapeWalk:       widget.NewEntry(),
+		LandscapeExhaustive: widget.NewEntry(),
+		LandscapeLabel:      widget.NewLabel("Not analysed yet"),
+
+		PheromoneUpdate: widget.NewRadioGroup([]string{"Elitist", "MAX-MIN"}, nil),
+		PheromoneAlpha:  widget.NewEntry(),
+		PheromoneBeta:   widget.NewEntry(),
+		Evaporation:     widget.NewEntry(),
+		AntLocalSearch:  widget.NewCheck("Augmenting-path local search for ants", nil),
+
+		InitialTemperature: widget.NewEntry(),
+		TabuTenure:         widget.NewEntry(),
+		TabuCandidates:     widget.NewEntry(),
+
+		StagnationResponse: widget.NewRadioGroup([]string{"None", "Partial Restart", "Hypermutation", "Random Immigrants", "Full Restart"}, nil),
+		StagnationWindow:   widget.NewEntry(),
+		HyperGenerations:   widget.NewEntry(),
+		ImmigrantFraction:  widget.NewEntry(),
+		RestartsLabel:      widget.NewLabel("No restarts"),
 	}
 	cp.setDefaults()
 
@@ -53,11 +258,13 @@ func NewControlsPanel() *ControlsPanel {
 	updateOperatorControls := func() {
 		enabled := cp.EvolutionModel.Selected == "Combined"
 		cp.CrossoverType.Disable()
+		cp.CrossoverAdapt.Disable()
 		cp.MutationType.Disable()
 		cp.SelectionType.Disable()
 		cp.TournamentSize.Disable()
 		if enabled {
 			cp.CrossoverType.Enable()
+			cp.CrossoverAdapt.Enable()
 			cp.MutationType.Enable()
 			cp.SelectionType.Enable()
 			cp.TournamentSize.Enable()
@@ -85,6 +292,53 @@ func NewControlsPanel() *ControlsPanel {
 			cp.OnPlot()
 		}
 	})
+
+	cp.LoadSeedBtn = widget.NewButton("Load Matching...", func() {
+		if cp.OnLoadMatching != nil {
+			cp.OnLoadMatching()
+		}
+	})
+	cp.SaveBestBtn = widget.NewButton("Save Best Matching...", func() {
+		if cp.OnSaveMatching != nil {
+			cp.OnSaveMatching()
+		}
+	})
+	cp.SeedBestBtn = widget.NewButton("Seed From Last Best", func() {
+		if cp.OnSeedFromBest != nil {
+			cp.OnSeedFromBest()
+		}
+	})
+	cp.ApplyCapacityBtn = widget.NewButton("Apply Capacities", func() {
+		if cp.OnCapacities != nil {
+			cp.OnCapacities()
+		}
+	})
+	cp.RandomPrefsBtn = widget.NewButton("Random Preferences", func() {
+		if cp.OnRandomPrefs != nil {
+			cp.OnRandomPrefs()
+		}
+	})
+	cp.LoadCostsBtn = widget.NewButton("Load Cost Matrix...", func() {
+		if cp.OnLoadCosts != nil {
+			cp.OnLoadCosts()
+		}
+	})
+	cp.TuneBtn = widget.NewButton("Tune Parameters", func() {
+		if cp.OnTune != nil {
+			cp.OnTune()
+		}
+	})
+	cp.StopTuneBtn = widget.NewButton("Stop Tuning", func() {
+		if cp.OnStopTuning != nil {
+			cp.OnStopTuning()
+		}
+	})
+	cp.StopTuneBtn.Disable()
+	cp.LandscapeBtn = widget.NewButton("Analyse Landscape", func() {
+		if cp.OnLandscape != nil {
+			cp.OnLandscape()
+		}
+	})
 	return cp
 }
 
@@ -95,12 +349,109 @@ func (cp *ControlsPanel) setDefaults() {
 	cp.CrossoverRate.SetText("0.8")
 	cp.NumIslands.SetText("4")
 	cp.MigrationInterval.SetText("10")
+	cp.Topology.SetSelected("Ring")
+	cp.Migrants.SetText("1")
+	cp.Emigrants.SetSelected("Best")
+	cp.Immigrants.SetSelected("Replace Worst")
 
 	cp.EvolutionModel.SetSelected("Classic")
+	cp.Problem.SetSelected("Matching")
 	cp.CrossoverType.SetSelected("Single-point")
+	cp.CrossoverAdapt.SetSelected("Fixed")
 	cp.MutationType.SetSelected("Classic")
 	cp.SelectionType.SetSelected("Tournament")
 	cp.TournamentSize.SetText("3")
+
+	cp.SharingRadius.SetText("0")
+	cp.Replacement.SetSelected("Generational")
+	cp.RTRWindow.SetText("10")
+
+	cp.Repair.SetSelected("Index Order")
+	cp.CacheSize.SetText("4096")
+
+	cp.LocalSearch.SetSelected("Single Augmentation")
+	cp.Learning.SetSelected("Lamarckian")
+	cp.LocalSearchFraction.SetText("1")
+	cp.DefaultCapacity.SetText("1")
+
+	cp.InitRandom.SetText("1")
+	cp.InitSparse.SetText("0")
+	cp.SparseDensity.SetText("0.1")
+	cp.InitGreedy.SetText("0")
+	cp.InitMinDegree.SetText("0")
+	cp.SeedFraction.SetText("0.1")
+
+	cp.MutationSchedule.SetSelected("Constant")
+	cp.FinalMutationRate.SetText("0.005")
+	cp.CrossoverSchedule.SetSelected("Constant")
+	cp.FinalCrossoverRate.SetText("0.5")
+	cp.RateAdaptation.SetSelected("None")
+
+	cp.SteadyOffspring.SetText("1")
+	cp.SteadyReplacement.SetSelected("Worst")
+
+	cp.CellNeighbourhood.SetSelected("Von Neumann")
+	cp.CellRadius.SetText("1")
+	cp.CellUpdate.SetSelected("Synchronous")
+
+	cp.EDAVariant.SetSelected("PBIL")
+	cp.EDALearningRate.SetText("0.1")
+	cp.EDASelectionRatio.SetText("0.5")
+
+	cp.TuningMethod.SetSelected("Racing")
+	cp.TuningCandidates.SetText("16")
+	cp.TuningSeeds.SetText("3")
+	cp.TuningSeed.SetText("0")
+
+	cp.LandscapeSamples.SetText("500")
+	cp.LandscapeWalk.SetText("1000")
+	cp.LandscapeExhaustive.SetText("20")
+
+	cp.PheromoneUpdate.SetSelected("MAX-MIN")
+	cp.PheromoneAlpha.SetText("1")
+	cp.PheromoneBeta.SetText("2")
+	cp.Evaporation.SetText("0.1")
+
+	cp.InitialTemperature.SetText("0")
+	cp.TabuTenure.SetText("0")
+	cp.TabuCandidates.SetText("20")
+
+	cp.StagnationResponse.SetSelected("None")
+	cp.StagnationWindow.SetText("20")
+	cp.HyperGenerations.SetText("5")
+	cp.ImmigrantFraction.SetText("0.2")
+}
+
+// rateScheduleOptions — названия расписаний вероятностей в порядке genetic.RateSchedule
+var rateScheduleOptions = []string{"Constant", "Linear", "Exponential", "Cosine", "Step"}
+
+// rateSchedule возвращает расписание, выбранное в группе
+func rateSchedule(rg *widget.RadioGroup) genetic.RateSchedule {
+	for i, name := range rateScheduleOptions {
+		if rg.Selected == name {
+			return genetic.RateSchedule(i)
+		}
+	}
+	return genetic.ScheduleConstant
+}
+
+// RateControl собирает настройки расписаний и адаптации вероятностей
+func (cp *ControlsPanel) RateControl() genetic.RateControlConfig {
+	finalMut, _ := strconv.ParseFloat(cp.FinalMutationRate.Text, 64)
+	finalCross, _ := strconv.ParseFloat(cp.FinalCrossoverRate.Text, 64)
+	rc := genetic.RateControlConfig{
+		MutationSchedule:   rateSchedule(cp.MutationSchedule),
+		FinalMutationRate:  finalMut,
+		CrossoverSchedule:  rateSchedule(cp.CrossoverSchedule),
+		FinalCrossoverRate: finalCross,
+	}
+	switch cp.RateAdaptation.Selected {
+	case "1/5 Success Rule":
+		rc.Adaptation = genetic.AdaptOneFifth
+	case "Self-Adaptive":
+		rc.Adaptation = genetic.AdaptSelf
+	}
+	return rc
 }
 
 func (cp *ControlsPanel) GetParams() backend.Params {
@@ -110,7 +461,18 @@ func (cp *ControlsPanel) GetParams() backend.Params {
 	crossRate, _ := strconv.ParseFloat(cp.CrossoverRate.Text, 64)
 	nIslands, _ := strconv.Atoi(cp.NumIslands.Text)
 	migInt, _ := strconv.Atoi(cp.MigrationInterval.Text)
+	migrants, _ := strconv.Atoi(cp.Migrants.Text)
 	tSize, _ := strconv.Atoi(cp.TournamentSize.Text)
+	sharing, _ := strconv.ParseFloat(cp.SharingRadius.Text, 64)
+	rtrWindow, _ := strconv.Atoi(cp.RTRWindow.Text)
+	lsFraction, _ := strconv.ParseFloat(cp.LocalSearchFraction.Text, 64)
+	wRandom, _ := strconv.ParseFloat(cp.InitRandom.Text, 64)
+	wSparse, _ := strconv.ParseFloat(cp.InitSparse.Text, 64)
+	density, _ := strconv.ParseFloat(cp.SparseDensity.Text, 64)
+	wGreedy, _ := strconv.ParseFloat(cp.InitGreedy.Text, 64)
+	wMinDegree, _ := strconv.ParseFloat(cp.InitMinDegree.Text, 64)
+	seedFraction, _ := strconv.ParseFloat(cp.SeedFraction.Text, 64)
+	cacheSize, _ := strconv.Atoi(cp.CacheSize.Text)
 
 	var model genetic.EvolutionModel
 	switch cp.EvolutionModel.Selected {
@@ -124,8 +486,22 @@ func (cp *ControlsPanel) GetParams() backend.Params {
 		model = genetic.Memetic
 	case "Combined":
 		model = genetic.Combined
+	case "NSGA-II":
+		model = genetic.NSGA2
+	case "Cellular":
+		model = genetic.Cellular
+	case "EDA":
+		model = genetic.EDA
+	case "Simulated Annealing":
+		model = genetic.SimulatedAnnealing
+	case "Tabu Search":
+		model = genetic.TabuSearch
+	case "Ant Colony":
+		model = genetic.AntColony
 	}
 
+	problem := cp.SelectedProblem()
+
 	var cross genetic.CrossoverStrategy
 	var mut genetic.MutationStrategy
 	var sel genetic.SelectionStrategy
@@ -136,7 +512,17 @@ func (cp *ControlsPanel) GetParams() backend.Params {
 		case "Two-point":
 			cross = &genetic.TwoPoint{}
 		case "Combined":
-			cross = &genetic.CombinedCrossover{}
+			adaptation := genetic.AdaptationNone
+			switch cp.CrossoverAdapt.Selected {
+			case "Probability Matching":
+				adaptation = genetic.ProbabilityMatching
+			case "Adaptive Pursuit":
+				adaptation = genetic.AdaptivePursuit
+			}
+			cross = genetic.NewCombinedCrossover(adaptation,
+				genetic.WeightedCrossover{Strategy: &genetic.SinglePoint{}, Weight: 1},
+				genetic.WeightedCrossover{Strategy: &genetic.TwoPoint{}, Weight: 1},
+			)
 		}
 
 		switch cp.MutationType.Selected {
@@ -163,6 +549,22 @@ func (cp *ControlsPanel) GetParams() backend.Params {
 			sel = &genetic.RouletteWheelSelectionStrategy{}
 		case "Rank":
 			sel = &genetic.RankSelectionStrategy{}
+		case "SUS":
+			sel = &genetic.StochasticUniversalSamplingStrategy{}
+		case "Boltzmann":
+			sel = &genetic.BoltzmannSelectionStrategy{
+				InitialTemperature: 10,
+				FinalTemperature:   0.1,
+				Schedule:           genetic.ExponentialCooling,
+			}
+		case "Truncation":
+			sel = &genetic.TruncationSelectionStrategy{Fraction: 0.5}
+		case "Linear Rank":
+			sel = &genetic.LinearRankingSelectionStrategy{Pressure: 1.5}
+		case "Exponential Rank":
+			sel = &genetic.ExponentialRankingSelectionStrategy{Base: 0.95}
+		case "Lexicase":
+			sel = &genetic.LexicaseSelectionStrategy{}
 		}
 	} else {
 		switch cp.EvolutionModel.Selected {
@@ -187,8 +589,109 @@ func (cp *ControlsPanel) GetParams() backend.Params {
 			mut = &genetic.ClassicMutationStrategy{}
 			sel = &genetic.TournamentSelectionStrategy{TournamentSize: tSize}
 		}
+		// Мутации островной и меметической моделей чинят геном как паросочетание
+		if problem != genetic.Matching {
+			mut = &genetic.ClassicMutationStrategy{}
+		}
 	}
 
+	diversity := genetic.DiversityConfig{
+		SharingRadius:       sharing,
+		RTRWindow:           rtrWindow,
+		EliminateDuplicates: cp.EliminateDupes.Checked,
+	}
+	switch cp.Replacement.Selected {
+	case "Deterministic Crowding":
+		diversity.Replacement = genetic.ReplaceDeterministicCrowding
+	case "Restricted Tournament":
+		diversity.Replacement = genetic.ReplaceRestrictedTournament
+	}
+
+	islands := genetic.IslandConfig{
+		Migration: genetic.MigrationPolicy{
+			Migrants: migrants,
+			Async:    cp.AsyncMigration.Checked,
+		},
+	}
+	switch cp.Topology.Selected {
+	case "Fully Connected":
+		islands.Topology = &genetic.FullyConnectedTopology{}
+	case "Star":
+		islands.Topology = &genetic.StarTopology{}
+	case "Random":
+		islands.Topology = &genetic.RandomTopology{Degree: 1}
+	case "Hypercube":
+		islands.Topology = &genetic.HypercubeTopology{}
+	default:
+		islands.Topology = &genetic.RingTopology{}
+	}
+	switch cp.Emigrants.Selected {
+	case "Random":
+		islands.Migration.Emigrants = genetic.EmigrateRandom
+	case "Diverse":
+		islands.Migration.Emigrants = genetic.EmigrateDiverse
+	}
+	if cp.Immigrants.Selected == "Replace Random" {
+		islands.Migration.Replacement = genetic.ReplaceRandomIndividual
+	}
+	if cp.Heterogeneous.Checked {
+		islands.Operators = heterogeneousIslandOperators(nIslands, tSize)
+	}
+
+	localSearch := genetic.LocalSearchConfig{Fraction: lsFraction}
+	switch cp.LocalSearch.Selected {
+	case "Repeated Augmentation":
+		localSearch.Search = &genetic.AugmentingPathSearch{}
+	case "2-opt":
+		localSearch.Search = &genetic.TwoOptSearch{}
+	case "Blossom":
+		localSearch.Search = &genetic.BlossomSearch{}
+	case "Bounded Depth":
+		localSearch.Search = &genetic.BoundedDepthSearch{MaxDepth: 5}
+	}
+	// «Single Augmentation» оставляет поиск незаданным: по умолчанию алгоритм выбирает одну
+	// увеличивающую цепь с учётом ёмкостей вершин, а для других задач — перевороты генов
+	if cp.Learning.Selected == "Baldwinian" {
+		localSearch.Learning = genetic.Baldwinian
+	}
+
+	config := genetic.Config{
+		PopulationSize:   popSize,
+		MutationRate:     mutRate,
+		Generations:      gens,
+		UseCachedFitness: cp.CacheFitness.Checked,
+		CacheSize:        cacheSize,
+	}
+	var repair genetic.RepairStrategy
+	switch cp.Repair.Selected {
+	case "Random Order":
+		repair = &genetic.RandomOrderRepair{}
+	case "Degree-Aware":
+		repair = &genetic.DegreeAwareRepair{}
+	default:
+		config.UseFastRepair = true
+		repair = &genetic.IndexOrderRepair{}
+	}
+	if cp.GreedyExtend.Checked {
+		repair = &genetic.GreedyExtendRepair{Base: repair}
+	}
+	config.Repair = repair
+
+	initialization := genetic.InitializationConfig{
+		Mix: []genetic.WeightedInitializer{
+			{Initializer: &genetic.RandomInitializer{}, Weight: wRandom},
+			{Initializer: &genetic.RandomInitializer{Density: density, ShuffleRepair: true}, Weight: wSparse},
+			{Initializer: &genetic.GreedyInitializer{}, Weight: wGreedy},
+			{Initializer: &genetic.MinDegreeInitializer{}, Weight: wMinDegree},
+		},
+		Seeds:        cp.Seeds,
+		SeedFraction: seedFraction,
+	}
+
+	temperature, _ := strconv.ParseFloat(cp.InitialTemperature.Text, 64)
+	tenure, _ := strconv.Atoi(cp.TabuTenure.Text)
+	candidates, _ := strconv.Atoi(cp.TabuCandidates.Text)
+
 	return backend.Params{
 		EvolutionModel:    model,
 		PopulationSize:    popSize,
@@ -198,9 +701,53 @@ func (cp *ControlsPanel) GetParams() backend.Params {
 		NumIslands:        nIslands,
 		MigrationInterval: migInt,
 		TournamentSize:    tSize,
+		Config:            config,
 		CrossoverStrategy: cross,
 		MutationStrategy:  mut,
 		SelectionStrategy: sel,
+		Diversity:         diversity,
+		Islands:           islands,
+		LocalSearch:       localSearch,
+		Initialization:    initialization,
+		Problem:           problem,
+		MultiObjective:    genetic.MultiObjectiveConfig{Preferred: cp.PreferredVertexList()},
+		RateControl:       cp.RateControl(),
+		Stagnation:        cp.Stagnation(),
+		SteadyState:       cp.SteadyState(),
+		Cellular:          cp.Cellular(),
+		EDA:               cp.EDA(),
+		Annealing:         genetic.AnnealingConfig{InitialTemperature: temperature},
+		Tabu:              genetic.TabuConfig{Tenure: tenure, Candidates: candidates},
+		ACO:               cp.ACO(),
+	}
+}
+
+// PreferredVertexList разбирает список предпочтительных вершин; нечисловые элементы пропускаются
+func (cp *ControlsPanel) PreferredVertexList() []int {
+	var vertices []int
+	for _, item := range strings.Split(cp.PreferredVertices.Text, ",") {
+		if v, err := strconv.Atoi(strings.TrimSpace(item)); err == nil {
+			vertices = append(vertices, v)
+		}
+	}
+	return vertices
+}
+
+// SelectedProblem возвращает задачу, выбранную в панели
+func (cp *ControlsPanel) SelectedProblem() genetic.ProblemType {
+	switch cp.Problem.Selected {
+	case "Independent Set":
+		return genetic.IndependentSet
+	case "Vertex Cover":
+		return genetic.VertexCover
+	case "Max Cut":
+		return genetic.MaxCut
+	case "Edge Dominating Set":
+		return genetic.EdgeDominatingSet
+	case "Stable Matching":
+		return genetic.StableMatching
+	default:
+		return genetic.Matching
 	}
 }
 
@@ -209,9 +756,12 @@ func (cp *ControlsPanel) Render() fyne.CanvasObject {
 	acc := widget.NewAccordion(
 		widget.NewAccordionItem("Evolution Model & Basic", container.NewVBox(
 			widget.NewLabel("Evolution Model:"), cp.EvolutionModel,
+			widget.NewLabel("Problem:"), cp.Problem,
+			cp.ProblemLabel,
 		)),
 		widget.NewAccordionItem("Genetic Operators", container.NewVBox(
 			widget.NewLabel("Crossover Type:"), cp.CrossoverType,
+			widget.NewLabel("Combined Crossover Weights:"), cp.CrossoverAdapt,
 			widget.NewLabel("Mutation Type:"), cp.MutationType,
 			widget.NewLabel("Selection Type:"), cp.SelectionType,
 			widget.NewLabel("Tournament Size:"), cp.TournamentSize,
@@ -225,6 +775,123 @@ func (cp *ControlsPanel) Render() fyne.CanvasObject {
 		widget.NewAccordionItem("Island Parameters", container.NewVBox(
 			widget.NewLabel("Num Islands:"), cp.NumIslands,
 			widget.NewLabel("Migration Interval:"), cp.MigrationInterval,
+			widget.NewLabel("Topology:"), cp.Topology,
+			widget.NewLabel("Migrants:"), cp.Migrants,
+			widget.NewLabel("Emigrants:"), cp.Emigrants,
+			widget.NewLabel("Immigrants:"), cp.Immigrants,
+			cp.AsyncMigration,
+			cp.Heterogeneous,
+		)),
+		widget.NewAccordionItem("Island Statistics", cp.IslandStats),
+		widget.NewAccordionItem("Cellular Grid", container.NewVBox(
+			widget.NewLabel("Neighbourhood:"), cp.CellNeighbourhood,
+			widget.NewLabel("Radius:"), cp.CellRadius,
+			widget.NewLabel("Update order:"), cp.CellUpdate,
+			cp.CellularGrid,
+		)),
+		widget.NewAccordionItem("Distribution (EDA)", container.NewVBox(
+			widget.NewLabel("Variant:"), cp.EDAVariant,
+			widget.NewLabel("PBIL learning rate:"), cp.EDALearningRate,
+			widget.NewLabel("UMDA selection ratio:"), cp.EDASelectionRatio,
+			widget.NewLabel("Edge thickness shows inclusion probability"),
+		)),
+		widget.NewAccordionItem("Ant Colony", container.NewVBox(
+			widget.NewLabel("Pheromone update:"), cp.PheromoneUpdate,
+			widget.NewLabel("Pheromone weight (alpha):"), cp.PheromoneAlpha,
+			widget.NewLabel("Degree heuristic weight (beta):"), cp.PheromoneBeta,
+			widget.NewLabel("Evaporation rate:"), cp.Evaporation,
+			cp.AntLocalSearch,
+			widget.NewLabel("Edge thickness shows pheromone intensity"),
+		)),
+		widget.NewAccordionItem("Baselines (Annealing / Tabu)", container.NewVBox(
+			widget.NewLabel("Initial temperature (0 = calibrate):"), cp.InitialTemperature,
+			widget.NewLabel("Tabu tenure (0 = sqrt of genome length):"), cp.TabuTenure,
+			widget.NewLabel("Tabu candidates per iteration:"), cp.TabuCandidates,
+		)),
+		widget.NewAccordionItem("Steady-State", container.NewVBox(
+			widget.NewLabel("Offspring per step:"), cp.SteadyOffspring,
+			widget.NewLabel("Replacement:"), cp.SteadyReplacement,
+		)),
+		widget.NewAccordionItem("Diversity", container.NewVBox(
+			widget.NewLabel("Sharing Radius (fraction of genome, 0 = off):"), cp.SharingRadius,
+			widget.NewLabel("Replacement:"), cp.Replacement,
+			widget.NewLabel("RTR Window:"), cp.RTRWindow,
+			cp.EliminateDupes,
+		)),
+		widget.NewAccordionItem("Repair", container.NewVBox(
+			widget.NewLabel("Repair Strategy:"), cp.Repair,
+			cp.GreedyExtend,
+		)),
+		widget.NewAccordionItem("Evaluation", container.NewVBox(
+			cp.CacheFitness,
+			widget.NewLabel("Cache Size:"), cp.CacheSize,
+		)),
+		widget.NewAccordionItem("Local Search (Memetic)", container.NewVBox(
+			widget.NewLabel("Local Search:"), cp.LocalSearch,
+			widget.NewLabel("Learning:"), cp.Learning,
+			widget.NewLabel("Fraction of Offspring:"), cp.LocalSearchFraction,
+		)),
+		widget.NewAccordionItem("Initialization", container.NewVBox(
+			widget.NewLabel("Random weight:"), cp.InitRandom,
+			widget.NewLabel("Sparse random weight:"), cp.InitSparse,
+			widget.NewLabel("Sparse density:"), cp.SparseDensity,
+			widget.NewLabel("Randomized greedy weight:"), cp.InitGreedy,
+			widget.NewLabel("Min-degree greedy weight:"), cp.InitMinDegree,
+			widget.NewLabel("Seed fraction:"), cp.SeedFraction,
+			cp.SeedsLabel,
+			cp.LoadSeedBtn,
+			cp.SeedBestBtn,
+			cp.SaveBestBtn,
+		)),
+		widget.NewAccordionItem("Assignment (Weighted)", container.NewVBox(
+			cp.LoadCostsBtn,
+			cp.RequirePerfect,
+			cp.AssignmentLabel,
+		)),
+		widget.NewAccordionItem("Vertex Capacities (b-matching)", container.NewVBox(
+			widget.NewLabel("Default capacity:"), cp.DefaultCapacity,
+			widget.NewLabel("Overrides (vertex:capacity, comma-separated):"), cp.CapacityOverrides,
+			cp.ApplyCapacityBtn,
+		)),
+		widget.NewAccordionItem("Preferences (Stable Matching)", container.NewVBox(
+			cp.RandomPrefsBtn,
+			cp.PreferencesLabel,
+		)),
+		widget.NewAccordionItem("Multi-objective (NSGA-II)", container.NewVBox(
+			widget.NewLabel("Preferred vertices (comma-separated):"), cp.PreferredVertices,
+			cp.ParetoLabel,
+			cp.ParetoPlot,
+		)),
+		widget.NewAccordionItem("Rate Control", container.NewVBox(
+			widget.NewLabel("Mutation schedule:"), cp.MutationSchedule,
+			widget.NewLabel("Final mutation rate:"), cp.FinalMutationRate,
+			widget.NewLabel("Crossover schedule:"), cp.CrossoverSchedule,
+			widget.NewLabel("Final crossover rate:"), cp.FinalCrossoverRate,
+			widget.NewLabel("Mutation adaptation:"), cp.RateAdaptation,
+			cp.RatesLabel,
+		)),
+		widget.NewAccordionItem("Parameter Tuning", container.NewVBox(
+			widget.NewLabel("Method:"), cp.TuningMethod,
+			widget.NewLabel("Candidate configurations:"), cp.TuningCandidates,
+			widget.NewLabel("Runs per graph (seeds 1..n):"), cp.TuningSeeds,
+			widget.NewLabel("Tuner seed (0 = random):"), cp.TuningSeed,
+			widget.NewLabel("Extra training graphs (current graph is always used):"), cp.TuningGraphs,
+			container.NewHBox(cp.TuneBtn, cp.StopTuneBtn),
+			cp.TuningLabel,
+		)),
+		widget.NewAccordionItem("Landscape Analysis", container.NewVBox(
+			widget.NewLabel("Random solutions (FDC, neutrality):"), cp.LandscapeSamples,
+			widget.NewLabel("Random walk length:"), cp.LandscapeWalk,
+			widget.NewLabel("Max edges for local optima enumeration:"), cp.LandscapeExhaustive,
+			cp.LandscapeBtn,
+			cp.LandscapeLabel,
+		)),
+		widget.NewAccordionItem("Stagnation & Restarts", container.NewVBox(
+			widget.NewLabel("Response:"), cp.StagnationResponse,
+			widget.NewLabel("Generations without improvement:"), cp.StagnationWindow,
+			widget.NewLabel("Hypermutation generations:"), cp.HyperGenerations,
+			widget.NewLabel("Immigrant fraction:"), cp.ImmigrantFraction,
+			cp.RestartsLabel,
 		)),
 	)
 	btns := container.NewHBox(
@@ -234,3 +901,334 @@ func (cp *ControlsPanel) Render() fyne.CanvasObject {
 	)
 	return container.NewBorder(nil, btns, nil, nil, acc)
 }
+
+// heterogeneousIslandOperators циклически раздаёт островам заранее подобранные наборы операторов
+func heterogeneousIslandOperators(numIslands, tournamentSize int) []genetic.IslandOperators {
+	presets := []func() genetic.IslandOperators{
+		func() genetic.IslandOperators {
+			return genetic.IslandOperators{
+				Selection:    &genetic.TournamentSelectionStrategy{TournamentSize: tournamentSize},
+				Crossover:    &genetic.TwoPoint{},
+				Mutation:     &genetic.AugmentingPathMutationStrategy{},
+				MutationRate: genetic.IslandRate(0.5),
+			}
+		},
+		func() genetic.IslandOperators {
+			return genetic.IslandOperators{
+				Selection:    &genetic.RouletteWheelSelectionStrategy{},
+				Crossover:    &genetic.SinglePoint{},
+				Mutation:     &genetic.ClassicMutationStrategy{},
+				MutationRate: genetic.IslandRate(0.2),
+			}
+		},
+		func() genetic.IslandOperators {
+			return genetic.IslandOperators{
+				Selection:    &genetic.RankSelectionStrategy{},
+				Crossover:    &genetic.TwoPoint{},
+				Mutation:     &genetic.ConflictAdaptiveMutationStrategy{},
+				MutationRate: genetic.IslandRate(0.05),
+			}
+		},
+		func() genetic.IslandOperators {
+			return genetic.IslandOperators{
+				Selection:    &genetic.TournamentSelectionStrategy{TournamentSize: tournamentSize},
+				Crossover:    &genetic.SinglePoint{},
+				Mutation:     &genetic.IslandMutationStrategy{},
+				MutationRate: genetic.IslandRate(0.05),
+			}
+		},
+	}
+
+	ops := make([]genetic.IslandOperators, 0, numIslands)
+	for i := 0; i < numIslands; i++ {
+		ops = append(ops, presets[i%len(presets)]())
+	}
+	return ops
+}
+
+// SetIslandStats выводит статистику островов в панель
+func (cp *ControlsPanel) SetIslandStats(stats []genetic.IslandStats) {
+	var sb strings.Builder
+	for _, st := range stats {
+		fmt.Fprintf(&sb, "Island %d: best=%d avg=%.1f unique=%d\n  %s / %s / %s (%.2f)\n",
+			st.Island, st.BestFitness, st.AverageFitness, st.UniqueGenomes,
+			st.Selection, st.Crossover, st.Mutation, st.MutationRate)
+	}
+	cp.IslandStats.SetText(sb.String())
+}
+
+// SetParetoFront показывает фронт Парето и его гиперобъём
+func (cp *ControlsPanel) SetParetoFront(front []genetic.ParetoPoint, objectives []string, hypervolume float64) {
+	cp.ParetoLabel.SetText(fmt.Sprintf("%s: %d solutions, hypervolume %.3f",
+		strings.Join(objectives, " vs "), len(front), hypervolume))
+	cp.ParetoPlot.SetFront(front, objectives)
+}
+
+// SetSeeds задаёт затравочные паросочетания начальной популяции
+func (cp *ControlsPanel) SetSeeds(seeds [][]bool) {
+	cp.Seeds = seeds
+	if len(seeds) == 0 {
+		cp.SeedsLabel.SetText("No seed matchings")
+		return
+	}
+	cp.SeedsLabel.SetText(fmt.Sprintf("Seed matchings: %d", len(seeds)))
+}
+
+// SetAssignmentResult показывает стоимость найденного назначения и разрыв с оптимумом
+func (cp *ControlsPanel) SetAssignmentResult(res backend.ExperimentResult) {
+	if !res.Weighted {
+		cp.AssignmentLabel.SetText("Unweighted graph")
+		return
+	}
+	status := "feasible"
+	switch {
+	case res.Imperfect:
+		status = "infeasible: matching is not perfect"
+	case !res.Feasible:
+		status = fmt.Sprintf("infeasible: fewer than %d edges", res.OptimalSize)
+	}
+	// При нулевой стоимости оптимума относительный разрыв не определён
+	gap := fmt.Sprintf("%.2f%%", 100*res.CostGap)
+	if res.OptimalCost == 0 {
+		gap = fmt.Sprintf("%+.3f", res.CostDelta)
+	}
+	cp.AssignmentLabel.SetText(fmt.Sprintf("Cost: %.3f\nOptimal (Hungarian): %.3f\nGap: %s (%s)",
+		res.BestCost, res.OptimalCost, gap, status))
+}
+
+// SetPreferences показывает, заданы ли списки предпочтений графа
+func (cp *ControlsPanel) SetPreferences(prefs [][]int) {
+	if prefs == nil {
+		cp.PreferencesLabel.SetText("No preference lists")
+		return
+	}
+	cp.PreferencesLabel.SetText(fmt.Sprintf("Preference lists for %d vertices", len(prefs)))
+}
+
+// SetProblemResult показывает лучшее решение задачи; для устойчивого паросочетания —
+// также сравнение с паросочетанием Гейла–Шепли
+func (cp *ControlsPanel) SetProblemResult(res backend.ExperimentResult) {
+	if !res.PreferenceBased {
+		cp.ProblemLabel.SetText(res.BestDescription)
+		return
+	}
+	best, ref := res.StableMetrics, res.GaleShapley
+	rank := "equal to"
+	switch genetic.CompareRankProfiles(best.RankProfile, ref.RankProfile) {
+	case 1:
+		rank = "better than"
+	case -1:
+		rank = "worse than"
+	}
+	cp.ProblemLabel.SetText(fmt.Sprintf("%s\nGale-Shapley: %d edges, egalitarian cost %d\nRank profile %v (%s Gale-Shapley %v)",
+		res.BestDescription, ref.Size, ref.EgalitarianCost, best.RankProfile, rank, ref.RankProfile))
+}
+
+// steadyReplacementOptions — названия политик замены в порядке genetic.SteadyStateReplacement
+var steadyReplacementOptions = []string{"Worst", "Random", "Oldest", "Parent", "Crowding"}
+
+// SteadyState собирает настройки модели с постоянным состоянием
+func (cp *ControlsPanel) SteadyState() genetic.SteadyStateConfig {
+	offspring, _ := strconv.Atoi(cp.SteadyOffspring.Text)
+	sc := genetic.SteadyStateConfig{Offspring: offspring}
+	for i, name := range steadyReplacementOptions {
+		if cp.SteadyReplacement.Selected == name {
+			sc.Replacement = genetic.SteadyStateReplacement(i)
+		}
+	}
+	return sc
+}
+
+// Cellular собирает настройки клеточной модели; решётка подбирается по размеру популяции
+func (cp *ControlsPanel) Cellular() genetic.CellularConfig {
+	radius, _ := strconv.Atoi(cp.CellRadius.Text)
+	cc := genetic.CellularConfig{Radius: radius}
+	if cp.CellNeighbourhood.Selected == "Moore" {
+		cc.Neighbourhood = genetic.Moore
+	}
+	switch cp.CellUpdate.Selected {
+	case "Line Sweep":
+		cc.Update = genetic.UpdateLineSweep
+	case "Random Sweep":
+		cc.Update = genetic.UpdateRandomSweep
+	}
+	return cc
+}
+
+// EDA собирает настройки модели оценки распределения
+func (cp *ControlsPanel) EDA() genetic.EDAConfig {
+	rate, _ := strconv.ParseFloat(cp.EDALearningRate.Text, 64)
+	ratio, _ := strconv.ParseFloat(cp.EDASelectionRatio.Text, 64)
+	ec := genetic.EDAConfig{LearningRate: rate, SelectionRatio: ratio}
+	switch cp.EDAVariant.Selected {
+	case "UMDA":
+		ec.Variant = genetic.UMDA
+	case "Compact GA":
+		ec.Variant = genetic.CompactGA
+	}
+	return ec
+}
+
+// ACO собирает настройки муравьиного алгоритма
+func (cp *ControlsPanel) ACO() genetic.ACOConfig {
+	alpha, _ := strconv.ParseFloat(cp.PheromoneAlpha.Text, 64)
+	beta, _ := strconv.ParseFloat(cp.PheromoneBeta.Text, 64)
+	evaporation, _ := strconv.ParseFloat(cp.Evaporation.Text, 64)
+	ac := genetic.ACOConfig{Alpha: alpha, Beta: beta, Evaporation: evaporation, LocalSearch: cp.AntLocalSearch.Checked}
+	if cp.PheromoneUpdate.Selected == "MAX-MIN" {
+		ac.Update = genetic.MaxMinAntSystem
+	}
+	return ac
+}
+
+// Tuner собирает настройки подбора параметров; базовые параметры берутся из панели,
+// обучающие графы задаёт вызывающий
+func (cp *ControlsPanel) Tuner() backend.TunerConfig {
+	candidates, _ := strconv.Atoi(cp.TuningCandidates.Text)
+	replicates, _ := strconv.Atoi(cp.TuningSeeds.Text)
+	seed, _ := strconv.ParseInt(cp.TuningSeed.Text, 10, 64)
+	tc := backend.TunerConfig{Base: cp.GetParams(), Candidates: candidates, Seed: seed}
+	for s := 1; s <= replicates; s++ {
+		tc.Seeds = append(tc.Seeds, int64(s))
+	}
+	switch cp.TuningMethod.Selected {
+	case "Racing":
+		tc.Method = backend.TuneRacing
+	case "Bayesian":
+		tc.Method = backend.TuneBayesian
+	}
+	return tc
+}
+
+// ApplyTuned записывает подобранные параметры в поля панели
+func (cp *ControlsPanel) ApplyTuned(p backend.Params) {
+	cp.PopulationSize.SetText(strconv.Itoa(p.PopulationSize))
+	cp.MutationRate.SetText(strconv.FormatFloat(p.MutationRate, 'f', 4, 64))
+	cp.CrossoverRate.SetText(strconv.FormatFloat(p.CrossoverRate, 'f', 3, 64))
+	cp.TournamentSize.SetText(strconv.Itoa(p.TournamentSize))
+	cp.NumIslands.SetText(strconv.Itoa(p.NumIslands))
+	cp.MigrationInterval.SetText(strconv.Itoa(p.MigrationInterval))
+}
+
+// Landscape собирает настройки анализа ландшафта; блуждания проходят под всеми операторами мутации
+func (cp *ControlsPanel) Landscape() genetic.LandscapeConfig {
+	samples, _ := strconv.Atoi(cp.LandscapeSamples.Text)
+	walk, _ := strconv.Atoi(cp.LandscapeWalk.Text)
+	exhaustive, _ := strconv.Atoi(cp.LandscapeExhaustive.Text)
+	return genetic.LandscapeConfig{Samples: samples, WalkLength: walk, ExhaustiveLimit: exhaustive}
+}
+
+// presetNames возвращает отсортированные названия предопределённых графов
+func presetNames() []string {
+	var names []string
+	for name := range genetic.PredefinedGraphs() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Stagnation собирает настройки реакции на стагнацию
+func (cp *ControlsPanel) Stagnation() genetic.StagnationConfig {
+	window, _ := strconv.Atoi(cp.StagnationWindow.Text)
+	hyperGens, _ := strconv.Atoi(cp.HyperGenerations.Text)
+	immigrants, _ := strconv.ParseFloat(cp.ImmigrantFraction.Text, 64)
+	sc := genetic.StagnationConfig{
+		Window:                   window,
+		HypermutationGenerations: hyperGens,
+		ImmigrantFraction:        immigrants,
+	}
+	switch cp.StagnationResponse.Selected {
+	case "Partial Restart":
+		sc.Response = genetic.RestartPartial
+	case "Hypermutation":
+		sc.Response = genetic.RestartHypermutation
+	case "Random Immigrants":
+		sc.Response = genetic.RestartImmigrants
+	case "Full Restart":
+		sc.Response = genetic.RestartFull
+	}
+	return sc
+}
+
+// SetRestartEvents показывает срабатывания реакции на стагнацию
+func (cp *ControlsPanel) SetRestartEvents(events []genetic.RestartEvent) {
+	if len(events) == 0 {
+		cp.RestartsLabel.SetText("No restarts")
+		return
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Restarts: %d", len(events))
+	for _, e := range events {
+		fmt.Fprintf(&sb, "\n  gen %d: %v after %d stagnant gens (best %d)", e.Generation, e.Response, e.Stagnated, e.BestEdges)
+		if e.Response == genetic.RestartFull {
+			fmt.Fprintf(&sb, ", seed %d", e.Seed)
+		}
+	}
+	cp.RestartsLabel.SetText(sb.String())
+}
+
+// SetRateHistory показывает начальные, минимальные и итоговые вероятности запуска
+func (cp *ControlsPanel) SetRateHistory(history []genetic.RatePoint) {
+	if len(history) == 0 {
+		cp.RatesLabel.SetText("Constant rates")
+		return
+	}
+	first, last := history[0], history[len(history)-1]
+	minMut, maxMut := first.MutationRate, first.MutationRate
+	for _, p := range history {
+		minMut, maxMut = min(minMut, p.MutationRate), max(maxMut, p.MutationRate)
+	}
+	cp.RatesLabel.SetText(fmt.Sprintf("Mutation: %.4f -> %.4f (range %.4f..%.4f)\nCrossover: %.3f -> %.3f",
+		first.MutationRate, last.MutationRate, minMut, maxMut, first.CrossoverRate, last.CrossoverRate))
+}
+
+// SetCapacities показывает ёмкости вершин графа: 1 по умолчанию и отличающиеся значения
+func (cp *ControlsPanel) SetCapacities(caps []int) {
+	cp.DefaultCapacity.SetText("1")
+	var overrides []string
+	for v, c := range caps {
+		if c != 1 {
+			overrides = append(overrides, fmt.Sprintf("%d:%d", v, c))
+		}
+	}
+	cp.CapacityOverrides.SetText(strings.Join(overrides, ", "))
+}
+
+// Capacities разбирает ёмкости вершин для графа из n вершин; nil означает ёмкость 1 у всех вершин
+func (cp *ControlsPanel) Capacities(n int) ([]int, error) {
+	def := 1
+	if text := strings.TrimSpace(cp.DefaultCapacity.Text); text != "" {
+		v, err := strconv.Atoi(text)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("invalid default capacity %q", text)
+		}
+		def = v
+	}
+	caps := make([]int, n)
+	for i := range caps {
+		caps[i] = def
+	}
+	for _, item := range strings.Split(cp.CapacityOverrides.Text, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid capacity override %q: expected vertex:capacity", item)
+		}
+		v, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+		c, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err1 != nil || err2 != nil || v < 0 || v >= n || c < 0 {
+			return nil, fmt.Errorf("invalid capacity override %q for %d vertices", item, n)
+		}
+		caps[v] = c
+	}
+	for _, c := range caps {
+		if c != 1 {
+			return caps, nil
+		}
+	}
+	return nil, nil
+}
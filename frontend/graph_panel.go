@@ -78,6 +78,14 @@ func (gw *GraphWidget) ApplyModel() {
 		label.TextSize = 12
 		label.Move(fyne.NewPos(float32(pos.X-6), float32(pos.Y-8)))
 		gw.container.Add(label)
+
+		// Ёмкость b-паросочетания подписывается рядом с вершиной, если она не равна 1
+		if gw.model.Capacities != nil && gw.model.Capacities[i] != 1 {
+			capLabel := canvas.NewText("b="+strconv.Itoa(gw.model.Capacities[i]), color.NRGBA{R: 250, G: 180, B: 50, A: 255})
+			capLabel.TextSize = 11
+			capLabel.Move(fyne.NewPos(float32(pos.X+10), float32(pos.Y-22)))
+			gw.container.Add(capLabel)
+		}
 	}
 }
 
@@ -109,6 +117,18 @@ func (gw *GraphWidget) updateEdgeColorsBestOnly(bestIndices map[int]struct{}) {
 	}
 }
 
+// updateEdgeWidths задаёт толщину рёбер по значениям из [0, 1] — вероятностям включения
+// модели оценки распределения или нормированному феромону; nil возвращает обычную толщину
+func (gw *GraphWidget) updateEdgeWidths(probs []float64) {
+	for idx, line := range gw.edges {
+		line.StrokeWidth = 2
+		if idx < len(probs) {
+			line.StrokeWidth = float32(1 + 6*probs[idx])
+		}
+		line.Refresh()
+	}
+}
+
 // ForEachEdge применяет функцию к каждому ребру
 func (gw *GraphWidget) ForEachEdge(f func(*canvas.Line)) {
 	for _, line := range gw.edges {
@@ -116,7 +136,34 @@ func (gw *GraphWidget) ForEachEdge(f func(*canvas.Line)) {
 	}
 }
 
+// updateVertexColors подсвечивает выбранные вершины; для разреза вершины раскрашиваются
+// по долям, а рёбра разреза выделяются
+func (gw *GraphWidget) updateVertexColors(chrom genetic.Chromosome, cut bool) {
+	selected := color.NRGBA{R: 255, G: 80, B: 80, A: 255}
+	plain := color.NRGBA{R: 50, G: 150, B: 250, A: 255}
+	for v, circle := range gw.vertices {
+		if v < len(chrom.Genes) && chrom.Genes[v] {
+			circle.FillColor = selected
+		} else {
+			circle.FillColor = plain
+		}
+		circle.Refresh()
+	}
+	for idx, line := range gw.edges {
+		e := gw.model.Edges[idx]
+		line.StrokeColor = color.NRGBA{R: 180, G: 180, B: 180, A: 255}
+		if cut && len(chrom.Genes) == len(gw.vertices) && chrom.Genes[e.U] != chrom.Genes[e.V] {
+			line.StrokeColor = color.NRGBA{R: 0, G: 150, B: 0, A: 255}
+		}
+		line.Refresh()
+	}
+}
+
 // Чтобы GUI вызывал подсветку:
 func (mw *MainWindow) updateGraph(chrom genetic.Chromosome) {
+	if mw.problem.EncodesVertices() {
+		mw.GraphWidget.updateVertexColors(chrom, mw.problem == genetic.MaxCut)
+		return
+	}
 	mw.GraphWidget.updateEdgeColors(chrom)
 }
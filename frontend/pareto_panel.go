@@ -0,0 +1,106 @@
+package frontend
+
+import (
+	"Genetic-algorithm/backend/genetic"
+	"fmt"
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Размеры диаграммы фронта Парето
+const (
+	paretoWidth  = 280
+	paretoHeight = 220
+	paretoMargin = 36
+)
+
+// ParetoPlot рисует точечную диаграмму фронта Парето по первым двум целям
+type ParetoPlot struct {
+	widget.BaseWidget
+	container *fyne.Container
+}
+
+func NewParetoPlot() *ParetoPlot {
+	pp := &ParetoPlot{container: container.NewWithoutLayout()}
+	pp.ExtendBaseWidget(pp)
+	pp.SetFront(nil, nil)
+	return pp
+}
+
+func (pp *ParetoPlot) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(pp.container)
+}
+
+func (pp *ParetoPlot) MinSize() fyne.Size {
+	return fyne.NewSize(paretoWidth, paretoHeight)
+}
+
+// SetFront перерисовывает диаграмму; names — названия целей для подписей осей
+func (pp *ParetoPlot) SetFront(front []genetic.ParetoPoint, names []string) {
+	axisColor := color.NRGBA{R: 180, G: 180, B: 180, A: 255}
+	left, bottom := float32(paretoMargin), float32(paretoHeight-paretoMargin)
+	right, top := float32(paretoWidth-8), float32(8)
+
+	objects := []fyne.CanvasObject{}
+	xAxis := canvas.NewLine(axisColor)
+	xAxis.Position1, xAxis.Position2 = fyne.NewPos(left, bottom), fyne.NewPos(right, bottom)
+	yAxis := canvas.NewLine(axisColor)
+	yAxis.Position1, yAxis.Position2 = fyne.NewPos(left, bottom), fyne.NewPos(left, top)
+	objects = append(objects, xAxis, yAxis)
+
+	if len(front) == 0 || len(names) < 2 {
+		empty := canvas.NewText("No Pareto front yet", axisColor)
+		empty.TextSize = 12
+		empty.Move(fyne.NewPos(left+10, (top+bottom)/2))
+		pp.container.Objects = append(objects, empty)
+		pp.container.Refresh()
+		return
+	}
+
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, p := range front {
+		minX, maxX = math.Min(minX, p.Objectives[0]), math.Max(maxX, p.Objectives[0])
+		minY, maxY = math.Min(minY, p.Objectives[1]), math.Max(maxY, p.Objectives[1])
+	}
+	// Вырожденный диапазон растягиваем, чтобы точки не сливались с осью
+	if maxX == minX {
+		minX, maxX = minX-1, maxX+1
+	}
+	if maxY == minY {
+		minY, maxY = minY-1, maxY+1
+	}
+	scale := func(v, lo, hi float64, from, to float32) float32 {
+		return from + float32((v-lo)/(hi-lo))*(to-from)
+	}
+
+	for _, p := range front {
+		x := scale(p.Objectives[0], minX, maxX, left+8, right-8)
+		y := scale(p.Objectives[1], minY, maxY, bottom-8, top+8)
+		dot := canvas.NewCircle(color.NRGBA{R: 255, G: 80, B: 80, A: 255})
+		dot.Resize(fyne.NewSize(7, 7))
+		dot.Move(fyne.NewPos(x-3.5, y-3.5))
+		objects = append(objects, dot)
+	}
+
+	label := func(text string, x, y float32) {
+		t := canvas.NewText(text, axisColor)
+		t.TextSize = 10
+		t.Move(fyne.NewPos(x, y))
+		objects = append(objects, t)
+	}
+	label(fmt.Sprintf("%g", minX), left, bottom+2)
+	label(fmt.Sprintf("%g", maxX), right-30, bottom+2)
+	label(names[0], (left+right)/2-20, bottom+14)
+	label(fmt.Sprintf("%g", maxY), 0, top)
+	label(fmt.Sprintf("%g", minY), 0, bottom-12)
+	label(names[1], left+4, top)
+
+	pp.container.Objects = objects
+	pp.container.Refresh()
+}